@@ -2,14 +2,93 @@ package gotmpl
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
 
 	"github.com/relychan/gotransform/transformtypes"
+	"github.com/relychan/goutils"
 )
 
+var (
+	// ErrInvalidTemplateDelims occurs when one of the template delimiters is empty.
+	ErrInvalidTemplateDelims = errors.New("left and right template delimiters must not be empty")
+	// ErrUnsupportedMissingKey occurs when the missing key option is not supported.
+	ErrUnsupportedMissingKey = errors.New("unsupported missing key option")
+	// ErrTemplateEntrypointRequired occurs when the main template is empty and the entrypoint is not set.
+	ErrTemplateEntrypointRequired = errors.New("entrypoint is required if the main template is empty")
+	// ErrTemplateEntrypointNotFound occurs when the entrypoint does not exist in the template set.
+	ErrTemplateEntrypointNotFound = errors.New("entrypoint template does not exist")
+	// ErrTemplateNameRequired occurs when a named template has an empty name.
+	ErrTemplateNameRequired = errors.New("name of the template must not be empty")
+	// ErrTemplatePartialNotFound occurs when a partial pattern does not match any file.
+	ErrTemplatePartialNotFound = errors.New("partial files do not exist")
+	// ErrTemplateFileNotFound occurs when the template file pattern does not match any file.
+	ErrTemplateFileNotFound = errors.New("template files do not exist")
+	// ErrTemplateSourceConflict occurs when both the inline template and the template file are set.
+	ErrTemplateSourceConflict = errors.New("template and templateFile are mutually exclusive")
+)
+
+// MissingKey represents the behavior enum of the template execution when a map is indexed with a key that is not present.
+type MissingKey string
+
+const (
+	// MissingKeyDefault prints "<no value>" for missing keys. This is the default behavior of Go templates.
+	MissingKeyDefault MissingKey = "default"
+	// MissingKeyZero returns the zero value of the map element type for missing keys.
+	MissingKeyZero MissingKey = "zero"
+	// MissingKeyError stops the execution with an error.
+	MissingKeyError MissingKey = "error"
+)
+
+var enumValuesMissingKey = []MissingKey{MissingKeyDefault, MissingKeyZero, MissingKeyError}
+
+// Validate checks if the missing key option is valid.
+func (mk MissingKey) Validate() error {
+	if !slices.Contains(enumValuesMissingKey, mk) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedMissingKey, mk)
+	}
+
+	return nil
+}
+
+// GoTemplateDelims represents the action delimiters of a Go template.
+type GoTemplateDelims struct {
+	// Left is the left delimiter. Defaults to {{.
+	Left string `json:"left" yaml:"left"`
+	// Right is the right delimiter. Defaults to }}.
+	Right string `json:"right" yaml:"right"`
+}
+
 // GoTemplateTransformerConfig represents configurations for the Go template transformer.
 type GoTemplateTransformerConfig struct {
 	ContentType string `json:"contentType" jsonschema:"default=application/json" yaml:"contentType"`
 	Template    string `json:"template"    yaml:"template"`
+	// TemplateFile is the path or glob pattern of template files, which is mutually exclusive with the template.
+	// Each file is a template named by its base name. The first matched file is executed unless the entrypoint is set.
+	TemplateFile string `json:"templateFile,omitempty" yaml:"templateFile,omitempty"`
+	// DecodeXML converts the rendered XML document to the map representation of the xmlmap package.
+	// Otherwise, the document is validated and returned as a string.
+	DecodeXML bool `json:"decodeXML,omitempty" yaml:"decodeXML,omitempty"`
+	// CSVHeader decodes the rendered CSV rows to records keyed by the columns of the first row.
+	// Otherwise, all rows are returned as arrays of strings.
+	CSVHeader bool `json:"csvHeader,omitempty" yaml:"csvHeader,omitempty"`
+	// Delims are custom action delimiters, e.g. if the rendered content contains {{ and }}.
+	Delims *GoTemplateDelims `json:"delims,omitempty" yaml:"delims,omitempty"`
+	// MissingKey is the behavior when a map is indexed with a key that is not present. Defaults to default.
+	MissingKey MissingKey `json:"missingKey,omitempty" yaml:"missingKey,omitempty"`
+	// Templates are named templates which are usable via {{ template "name" . }}.
+	Templates map[string]string `json:"templates,omitempty" yaml:"templates,omitempty"`
+	// Entrypoint is the name of the template to be executed. Defaults to the main template.
+	Entrypoint string `json:"entrypoint,omitempty" yaml:"entrypoint,omitempty"`
+	// Partials are paths or glob patterns of files which define shared templates.
+	Partials []string `json:"partials,omitempty" yaml:"partials,omitempty"`
+	// Functions is the policy of functions which are available to the template.
+	Functions *GoTemplateFunctions `json:"functions,omitempty" yaml:"functions,omitempty"`
+	// Limits are resource limits of the template execution.
+	Limits *GoTemplateLimits `json:"limits,omitempty" yaml:"limits,omitempty"`
 }
 
 var _ transformtypes.TemplateTransformerConfig = (*GoTemplateTransformerConfig)(nil)
@@ -21,40 +100,171 @@ func (GoTemplateTransformerConfig) Type() transformtypes.TransformTemplateType {
 
 // IsZero checks if the config is empty.
 func (gt GoTemplateTransformerConfig) IsZero() bool {
-	return gt.ContentType == "" && gt.Template == ""
+	return gt.ContentType == "" && gt.Template == "" && gt.TemplateFile == "" && !gt.DecodeXML && !gt.CSVHeader &&
+		gt.Delims == nil && gt.MissingKey == "" &&
+		len(gt.Templates) == 0 && gt.Entrypoint == "" && len(gt.Partials) == 0 &&
+		(gt.Functions == nil || gt.Functions.IsZero()) &&
+		(gt.Limits == nil || gt.Limits.IsZero())
 }
 
 // Equal checks if this instance equals the target value.
 func (gt GoTemplateTransformerConfig) Equal(target GoTemplateTransformerConfig) bool {
 	return gt.ContentType == target.ContentType &&
-		gt.Template == target.Template
+		gt.Template == target.Template &&
+		gt.TemplateFile == target.TemplateFile &&
+		gt.DecodeXML == target.DecodeXML &&
+		gt.CSVHeader == target.CSVHeader &&
+		gt.MissingKey == target.MissingKey &&
+		gt.Entrypoint == target.Entrypoint &&
+		maps.Equal(gt.Templates, target.Templates) &&
+		slices.Equal(gt.Partials, target.Partials) &&
+		goutils.EqualPtr(gt.Functions, target.Functions) &&
+		goutils.EqualPtr(gt.Limits, target.Limits) &&
+		(gt.Delims == target.Delims ||
+			(gt.Delims != nil && target.Delims != nil && *gt.Delims == *target.Delims))
 }
 
 // Validate checks if the config is valid.
 func (gt GoTemplateTransformerConfig) Validate() error {
-	if gt.Template == "" {
-		return transformtypes.ErrTemplateContentRequired
+	if gt.TemplateFile != "" {
+		if gt.Template != "" {
+			return ErrTemplateSourceConflict
+		}
+
+		_, err := path.Match(gt.TemplateFile, "")
+		if err != nil {
+			return fmt.Errorf("invalid templateFile %q: %w", gt.TemplateFile, err)
+		}
+	} else if gt.Template == "" {
+		if len(gt.Templates) == 0 && len(gt.Partials) == 0 {
+			return transformtypes.ErrTemplateContentRequired
+		}
+
+		if gt.Entrypoint == "" {
+			return ErrTemplateEntrypointRequired
+		}
+	}
+
+	if _, ok := gt.Templates[""]; ok {
+		return ErrTemplateNameRequired
+	}
+
+	return gt.validateOptions()
+}
+
+// validateOptions checks if the parsing and execution options are valid.
+func (gt GoTemplateTransformerConfig) validateOptions() error {
+	if gt.Delims != nil && (gt.Delims.Left == "" || gt.Delims.Right == "") {
+		return ErrInvalidTemplateDelims
+	}
+
+	if gt.MissingKey != "" {
+		err := gt.MissingKey.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if gt.Functions != nil {
+		err := gt.Functions.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if gt.Limits != nil {
+		return gt.Limits.Validate()
 	}
 
 	return nil
 }
 
-// MarshalJSON implements the json.Marshaler interface.
-func (gt GoTemplateTransformerConfig) MarshalJSON() ([]byte, error) {
-	result := map[string]any{
-		"type":        gt.Type(),
-		"contentType": gt.ContentType,
-		"template":    gt.Template,
+// evaluateLimits returns the limiter of template executions, or nil if the execution is unlimited.
+func (gt GoTemplateTransformerConfig) evaluateLimits() (*executionLimiter, error) {
+	if gt.Limits == nil || gt.Limits.IsZero() {
+		return nil, nil
+	}
+
+	return gt.Limits.evaluate()
+}
+
+// delims returns the left and right action delimiters. Empty delimiters are the defaults of Go templates.
+func (gt GoTemplateTransformerConfig) delims() (string, string) {
+	if gt.Delims == nil {
+		return "", ""
 	}
 
-	return json.Marshal(result)
+	return gt.Delims.Left, gt.Delims.Right
+}
+
+// parseOptions returns options of the template set.
+func (gt GoTemplateTransformerConfig) parseOptions() []string {
+	if gt.MissingKey == "" {
+		return nil
+	}
+
+	return []string{"missingkey=" + string(gt.MissingKey)}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (gt GoTemplateTransformerConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(gt.toMap())
 }
 
 // MarshalYAML implements the yaml.Marshaler interface.
 func (gt GoTemplateTransformerConfig) MarshalYAML() (any, error) {
-	return map[string]any{
+	return gt.toMap(), nil
+}
+
+func (gt GoTemplateTransformerConfig) toMap() map[string]any {
+	result := map[string]any{
 		"type":        gt.Type(),
 		"contentType": gt.ContentType,
 		"template":    gt.Template,
-	}, nil
+	}
+
+	if gt.DecodeXML {
+		result["decodeXML"] = true
+	}
+
+	if gt.CSVHeader {
+		result["csvHeader"] = true
+	}
+
+	if gt.Delims != nil {
+		result["delims"] = map[string]any{
+			"left":  gt.Delims.Left,
+			"right": gt.Delims.Right,
+		}
+	}
+
+	if gt.MissingKey != "" {
+		result["missingKey"] = gt.MissingKey
+	}
+
+	if len(gt.Templates) > 0 {
+		result["templates"] = gt.Templates
+	}
+
+	if gt.TemplateFile != "" {
+		result["templateFile"] = gt.TemplateFile
+	}
+
+	if gt.Entrypoint != "" {
+		result["entrypoint"] = gt.Entrypoint
+	}
+
+	if len(gt.Partials) > 0 {
+		result["partials"] = gt.Partials
+	}
+
+	if gt.Functions != nil && !gt.Functions.IsZero() {
+		result["functions"] = gt.Functions.toMap()
+	}
+
+	if gt.Limits != nil && !gt.Limits.IsZero() {
+		result["limits"] = gt.Limits.toMap()
+	}
+
+	return result
 }
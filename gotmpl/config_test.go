@@ -2,6 +2,7 @@ package gotmpl
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/relychan/gotransform/transformtypes"
@@ -94,6 +95,25 @@ func TestGoTemplateTransformerConfig_Equal(t *testing.T) {
 			t.Error("expected configs to be different")
 		}
 	})
+
+	t.Run("delims", func(t *testing.T) {
+		config1 := GoTemplateTransformerConfig{
+			Template: "[[.name]]",
+			Delims:   &GoTemplateDelims{Left: "[[", Right: "]]"},
+		}
+		config2 := GoTemplateTransformerConfig{
+			Template: "[[.name]]",
+			Delims:   &GoTemplateDelims{Left: "[[", Right: "]]"},
+		}
+		if !config1.Equal(config2) {
+			t.Error("expected configs to be equal")
+		}
+
+		config2.Delims = nil
+		if config1.Equal(config2) {
+			t.Error("expected configs to be different")
+		}
+	})
 }
 
 func TestGoTemplateTransformerConfig_Validate(t *testing.T) {
@@ -120,6 +140,78 @@ func TestGoTemplateTransformerConfig_Validate(t *testing.T) {
 			t.Errorf("expected error to be ErrTemplateContentRequired, got: %v", err)
 		}
 	})
+
+	t.Run("empty delimiter", func(t *testing.T) {
+		config := GoTemplateTransformerConfig{
+			Template: "{{.name}}",
+			Delims:   &GoTemplateDelims{Left: "[["},
+		}
+		err := config.Validate()
+		if !errors.Is(err, ErrInvalidTemplateDelims) {
+			t.Errorf("expected error to be ErrInvalidTemplateDelims, got: %v", err)
+		}
+	})
+
+	t.Run("named templates without entrypoint", func(t *testing.T) {
+		config := GoTemplateTransformerConfig{
+			Templates: map[string]string{"email": "{{.name}}"},
+		}
+		err := config.Validate()
+		if !errors.Is(err, ErrTemplateEntrypointRequired) {
+			t.Errorf("expected error to be ErrTemplateEntrypointRequired, got: %v", err)
+		}
+
+		config.Entrypoint = "email"
+		if err := config.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	t.Run("template file", func(t *testing.T) {
+		config := GoTemplateTransformerConfig{
+			TemplateFile: "templates/*.html",
+		}
+		if err := config.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+
+		config.Template = "{{.name}}"
+		err := config.Validate()
+		if !errors.Is(err, ErrTemplateSourceConflict) {
+			t.Errorf("expected error to be ErrTemplateSourceConflict, got: %v", err)
+		}
+	})
+
+	t.Run("invalid template file pattern", func(t *testing.T) {
+		config := GoTemplateTransformerConfig{
+			TemplateFile: "templates/[",
+		}
+		if err := config.Validate(); err == nil {
+			t.Error("expected error for invalid pattern, got nil")
+		}
+	})
+
+	t.Run("empty template name", func(t *testing.T) {
+		config := GoTemplateTransformerConfig{
+			Template:  "{{.name}}",
+			Templates: map[string]string{"": "{{.name}}"},
+		}
+		err := config.Validate()
+		if !errors.Is(err, ErrTemplateNameRequired) {
+			t.Errorf("expected error to be ErrTemplateNameRequired, got: %v", err)
+		}
+	})
+
+	t.Run("unsupported missing key", func(t *testing.T) {
+		config := GoTemplateTransformerConfig{
+			Template:   "{{.name}}",
+			MissingKey: "invalid",
+		}
+		err := config.Validate()
+		if !errors.Is(err, ErrUnsupportedMissingKey) {
+			t.Errorf("expected error to be ErrUnsupportedMissingKey, got: %v", err)
+		}
+	})
 }
 
 func TestGoTemplateTransformerConfig_MarshalJSON(t *testing.T) {
@@ -0,0 +1,97 @@
+package gotmpl
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrDuplicatedCSVHeader occurs when the header row of the CSV output contains duplicated column names.
+var ErrDuplicatedCSVHeader = errors.New("duplicated CSV header column")
+
+// decodeFormOutput parses the rendered form-urlencoded output. Leading and trailing spaces are ignored.
+func decodeFormOutput(output []byte) (any, error) {
+	values, err := url.ParseQuery(strings.TrimSpace(string(output)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse form-urlencoded result: %w", err)
+	}
+
+	return map[string][]string(values), nil
+}
+
+// decodeCSVOutput parses the rendered CSV output into rows.
+// If the header option is enabled, the first row is the header and other rows are decoded into records keyed by column names.
+func decodeCSVOutput(output []byte, header bool) (any, error) {
+	rows, err := csv.NewReader(bytes.NewReader(output)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV result: %w", err)
+	}
+
+	if !header {
+		if rows == nil {
+			rows = [][]string{}
+		}
+
+		return rows, nil
+	}
+
+	records := []map[string]string{}
+
+	if len(rows) == 0 {
+		return records, nil
+	}
+
+	columns := rows[0]
+	seen := make(map[string]bool, len(columns))
+
+	for _, column := range columns {
+		if seen[column] {
+			return nil, fmt.Errorf("failed to parse CSV result: %w: %s", ErrDuplicatedCSVHeader, column)
+		}
+
+		seen[column] = true
+	}
+
+	for _, row := range rows[1:] {
+		record := make(map[string]string, len(columns))
+
+		for i, column := range columns {
+			record[column] = row[i]
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// normalizeYAMLValue converts maps with non-string keys of the decoded YAML value to string-keyed maps recursively.
+func normalizeYAMLValue(value any) any {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for key, item := range typedValue {
+			typedValue[key] = normalizeYAMLValue(item)
+		}
+
+		return typedValue
+	case map[any]any:
+		result := make(map[string]any, len(typedValue))
+
+		for key, item := range typedValue {
+			result[fmt.Sprint(key)] = normalizeYAMLValue(item)
+		}
+
+		return result
+	case []any:
+		for i, item := range typedValue {
+			typedValue[i] = normalizeYAMLValue(item)
+		}
+
+		return typedValue
+	default:
+		return value
+	}
+}
@@ -0,0 +1,170 @@
+package gotmpl
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+const (
+	lettersAlpha   = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	lettersNumeric = "0123456789"
+	// printable ASCII characters from space to tilde.
+	asciiMin = ' '
+	asciiMax = '~'
+)
+
+// htmlDateFormat is the date format of sprig html date functions.
+const htmlDateFormat = "2006-01-02"
+
+// sprigDurationRound is the durationRound function of sprig which formats durations without the current time.
+var sprigDurationRound, _ = sprig.GenericFuncMap()["durationRound"].(func(any) string)
+
+// clockFuncs returns sprig time functions which depend on the current time, evaluated with the clock.
+// Date functions fall back to the current time of the clock if the date is not a time or a UNIX timestamp.
+func clockFuncs(clock func() time.Time) template.FuncMap {
+	dateInZone := func(format string, date any, zone string) string {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			loc = time.UTC
+		}
+
+		return clockDate(clock, date).In(loc).Format(format)
+	}
+
+	return template.FuncMap{
+		"now": clock,
+		"ago": func(date any) string {
+			return clock().Sub(clockDate(clock, date)).Round(time.Second).String()
+		},
+		"date": func(format string, date any) string {
+			return dateInZone(format, date, "Local")
+		},
+		"dateInZone":   dateInZone,
+		"date_in_zone": dateInZone,
+		"htmlDate": func(date any) string {
+			return dateInZone(htmlDateFormat, date, "Local")
+		},
+		"htmlDateInZone": func(date any, zone string) string {
+			return dateInZone(htmlDateFormat, date, zone)
+		},
+		"durationRound": func(duration any) string {
+			if date, ok := duration.(time.Time); ok {
+				duration = int64(clock().Sub(date))
+			}
+
+			return sprigDurationRound(duration)
+		},
+	}
+}
+
+// clockDate converts the date argument of sprig date functions to a time. The clock is used for other values.
+func clockDate(clock func() time.Time, date any) time.Time {
+	switch typedDate := date.(type) {
+	case time.Time:
+		return typedDate
+	case *time.Time:
+		if typedDate != nil {
+			return *typedDate
+		}
+	case int64:
+		return time.Unix(typedDate, 0)
+	case int:
+		return time.Unix(int64(typedDate), 0)
+	case int32:
+		return time.Unix(int64(typedDate), 0)
+	}
+
+	return clock()
+}
+
+// lockedRand is a random generator which is safe for concurrent executions of templates.
+type lockedRand struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func (lr *lockedRand) intN(n int) int {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	return lr.rand.IntN(n)
+}
+
+func (lr *lockedRand) read(size int) []byte {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	result := make([]byte, size)
+
+	for i := range result {
+		result[i] = byte(lr.rand.Uint32())
+	}
+
+	return result
+}
+
+func (lr *lockedRand) stringFrom(size int, letters string) string {
+	result := make([]byte, size)
+
+	for i := range result {
+		result[i] = letters[lr.intN(len(letters))]
+	}
+
+	return string(result)
+}
+
+// randomFuncs returns sprig random functions which generate values from the random source.
+func randomFuncs(source rand.Source) template.FuncMap {
+	random := &lockedRand{rand: rand.New(source)} //nolint:gosec
+
+	var ascii []byte
+
+	for c := byte(asciiMin); c <= asciiMax; c++ {
+		ascii = append(ascii, c)
+	}
+
+	return template.FuncMap{
+		"randAlphaNum": func(size int) string {
+			return random.stringFrom(size, lettersAlpha+lettersNumeric)
+		},
+		"randAlpha": func(size int) string {
+			return random.stringFrom(size, lettersAlpha)
+		},
+		"randNumeric": func(size int) string {
+			return random.stringFrom(size, lettersNumeric)
+		},
+		"randAscii": func(size int) string {
+			return random.stringFrom(size, string(ascii))
+		},
+		"randBytes": func(size int) (string, error) {
+			return base64.StdEncoding.EncodeToString(random.read(size)), nil
+		},
+		"randInt": func(minValue, maxValue int) int {
+			return minValue + random.intN(maxValue-minValue)
+		},
+		"shuffle": func(value string) string {
+			runes := []rune(value)
+
+			for i := len(runes) - 1; i > 0; i-- {
+				j := random.intN(i + 1)
+				runes[i], runes[j] = runes[j], runes[i]
+			}
+
+			return string(runes)
+		},
+		"uuidv4": func() string {
+			id := random.read(16) //nolint:mnd
+			// set the version 4 and the RFC 4122 variant.
+			id[6] = (id[6] & 0x0f) | 0x40 //nolint:mnd
+			id[8] = (id[8] & 0x3f) | 0x80 //nolint:mnd
+
+			return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:])
+		},
+	}
+}
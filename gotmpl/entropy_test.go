@@ -0,0 +1,132 @@
+package gotmpl
+
+import (
+	"math/rand/v2"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestWithClock(t *testing.T) {
+	clock := func() time.Time {
+		return time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	}
+
+	transformer, err := NewGoTemplateTransformer(
+		"test",
+		&GoTemplateTransformerConfig{
+			ContentType: "text/plain",
+			Template:    `{{ now | date "2006-01-02T15:04:05" }} {{ ago .createdAt }}`,
+		},
+		WithClock(clock),
+	)
+	if err != nil {
+		t.Fatalf("failed to create transformer: %v", err)
+	}
+
+	result, err := transformer.Transform(map[string]any{
+		"createdAt": clock().Add(-90 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := "2024-05-06T07:08:09 1h30m0s"
+	if result != expected {
+		t.Errorf("expected result to be %q, got: %q", expected, result)
+	}
+}
+
+func TestWithClock_DateFuncs(t *testing.T) {
+	clock := func() time.Time {
+		return time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		Template string
+		Expected string
+	}{
+		{Template: `{{ date "2006" "" }}`, Expected: "2024"},
+		{Template: `{{ dateInZone "2006-01-02T15:04" "" "UTC" }}`, Expected: "2024-05-06T07:08"},
+		{Template: `{{ date_in_zone "2006-01-02T15:04" 0 "UTC" }}`, Expected: "1970-01-01T00:00"},
+		{Template: `{{ htmlDateInZone "" "UTC" }}`, Expected: "2024-05-06"},
+		{Template: `{{ htmlDate "" | len }}`, Expected: "10"},
+		{Template: `{{ durationRound .createdAt }}`, Expected: "2d"},
+		{Template: `{{ durationRound "2h30m" }}`, Expected: "2h"},
+		{Template: `{{ ago "" }}`, Expected: "0s"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Template, func(t *testing.T) {
+			transformer, err := NewGoTemplateTransformer(
+				"test",
+				&GoTemplateTransformerConfig{
+					ContentType: "text/plain",
+					Template:    tc.Template,
+				},
+				WithClock(clock),
+			)
+			if err != nil {
+				t.Fatalf("failed to create transformer: %v", err)
+			}
+
+			for range 2 {
+				result, err := transformer.Transform(map[string]any{
+					"createdAt": clock().Add(-50 * time.Hour),
+				})
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+
+				if result != tc.Expected {
+					t.Errorf("expected result to be %q, got: %q", tc.Expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestWithRandSource(t *testing.T) {
+	template := `{{ randAlphaNum 8 }} {{ randAlpha 4 }} {{ randNumeric 4 }} {{ randAscii 4 }} ` +
+		`{{ randBytes 6 }} {{ randInt 10 20 }} {{ shuffle "abcdef" }} {{ uuidv4 }}`
+
+	render := func(seed uint64) any {
+		t.Helper()
+
+		transformer, err := NewGoTemplateTransformer(
+			"test",
+			&GoTemplateTransformerConfig{
+				ContentType: "text/plain",
+				Template:    template,
+			},
+			WithRandSource(rand.NewPCG(seed, seed)),
+		)
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		result, err := transformer.Transform(nil)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		return result
+	}
+
+	first := render(1)
+	if second := render(1); first != second {
+		t.Errorf("expected reproducible outputs, got: %q and %q", first, second)
+	}
+
+	if other := render(2); first == other {
+		t.Errorf("expected different outputs of different seeds, got: %q", first)
+	}
+
+	pattern := regexp.MustCompile(
+		`^[a-zA-Z0-9]{8} [a-zA-Z]{4} [0-9]{4} [ -~]{4} [A-Za-z0-9+/=]{8} 1[0-9] [a-f]{6} ` +
+			`[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+	)
+	if !pattern.MatchString(first.(string)) {
+		t.Errorf("unexpected output format: %q", first)
+	}
+}
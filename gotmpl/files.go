@@ -0,0 +1,84 @@
+package gotmpl
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// templateFiles resolves template files from a file system, or from the operating system if it is nil.
+type templateFiles struct {
+	fsys fs.FS
+}
+
+// glob returns the names of all files matching the pattern.
+func (tf templateFiles) glob(pattern string) ([]string, error) {
+	if tf.fsys == nil {
+		return filepath.Glob(pattern)
+	}
+
+	return fs.Glob(tf.fsys, pattern)
+}
+
+// readFile reads the content of the named file.
+func (tf templateFiles) readFile(name string) ([]byte, error) {
+	if tf.fsys == nil {
+		return os.ReadFile(name) //nolint:gosec
+	}
+
+	return fs.ReadFile(tf.fsys, name)
+}
+
+// base returns the last element of the file name.
+func (tf templateFiles) base(name string) string {
+	if tf.fsys == nil {
+		return filepath.Base(name)
+	}
+
+	return path.Base(name)
+}
+
+// parseGlob parses all files matching the pattern into the template set.
+// Templates are named by file paths, or by base names of files if the baseName flag is enabled.
+// The names of templates are returned in the lexical order.
+func parseGlob[T templateSet[T]](
+	tf templateFiles,
+	root T,
+	pattern string,
+	baseName bool,
+	notFoundErr error,
+) ([]string, error) {
+	paths, err := tf.glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file pattern %q: %w", pattern, err)
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: %s", notFoundErr, pattern)
+	}
+
+	names := make([]string, len(paths))
+
+	for i, filePath := range paths {
+		content, err := tf.readFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template file %q: %w", filePath, err)
+		}
+
+		templateName := filePath
+		if baseName {
+			templateName = tf.base(filePath)
+		}
+
+		_, err = root.New(templateName).Parse(string(content))
+		if err != nil {
+			return nil, err
+		}
+
+		names[i] = templateName
+	}
+
+	return names, nil
+}
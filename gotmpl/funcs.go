@@ -0,0 +1,152 @@
+package gotmpl
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"sync"
+	"text/template"
+	"unicode"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+var (
+	// ErrTemplateFunctionConflict occurs when the name of a custom function is used by sprig or another custom function.
+	ErrTemplateFunctionConflict = errors.New("template function name is already in use")
+	// ErrInvalidTemplateFunction occurs when the name or the signature of a custom function is invalid.
+	ErrInvalidTemplateFunction = errors.New("invalid template function")
+)
+
+var errorType = reflect.TypeFor[error]()
+
+// funcRegistry holds custom functions which are available to all Go template transformers.
+var funcRegistry = struct {
+	sync.RWMutex
+
+	funcs template.FuncMap
+}{
+	funcs: template.FuncMap{},
+}
+
+// RegisterFuncs adds custom functions to the global registry which are available to all Go template transformers
+// created afterwards. Names must not be used by sprig or functions which are registered before.
+func RegisterFuncs(funcs template.FuncMap) error {
+	funcRegistry.Lock()
+	defer funcRegistry.Unlock()
+
+	registered := maps.Clone(funcRegistry.funcs)
+
+	err := mergeFuncs(registered, funcs)
+	if err != nil {
+		return err
+	}
+
+	funcRegistry.funcs = registered
+
+	return nil
+}
+
+// NewFuncMap returns functions which are available to Go templates with the function policy and options,
+// i.e. sprig functions, registered functions and custom functions of [WithFuncs].
+// Sprig functions are replaced if the clock or the random source is set. The policy is not applied if nil.
+func NewFuncMap(functions *GoTemplateFunctions, options ...GoTemplateTransformerOption) (template.FuncMap, error) {
+	opts := &goTemplateTransformerOptions{}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	return opts.funcMap(functions)
+}
+
+// buildFuncMap merges sprig functions, registered functions and custom functions of the transformer.
+// Sprig functions are replaced by overrides with the same names.
+func buildFuncMap(overrides template.FuncMap, customFuncs []template.FuncMap) (template.FuncMap, error) {
+	result := sprig.TxtFuncMap()
+
+	maps.Copy(result, overrides)
+
+	funcRegistry.RLock()
+	err := mergeFuncs(result, funcRegistry.funcs)
+	funcRegistry.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, funcs := range customFuncs {
+		err := mergeFuncs(result, funcs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// mergeFuncs validates and adds custom functions to the function map. Existing names are rejected.
+func mergeFuncs(dest template.FuncMap, funcs template.FuncMap) error {
+	for name, fn := range funcs {
+		if _, ok := sprigFuncNames[name]; ok {
+			return fmt.Errorf("%w: %s is a sprig function", ErrTemplateFunctionConflict, name)
+		}
+
+		if _, ok := dest[name]; ok {
+			return fmt.Errorf("%w: %s", ErrTemplateFunctionConflict, name)
+		}
+
+		err := validateFunc(name, fn)
+		if err != nil {
+			return err
+		}
+
+		dest[name] = fn
+	}
+
+	return nil
+}
+
+// sprigFuncNames is the set of function names of the sprig library.
+var sprigFuncNames = sprig.GenericFuncMap()
+
+// validateFunc checks the requirements of text/template so that the template parser does not panic.
+// The name must be an identifier. The function must return a single value, or a value and an error.
+func validateFunc(name string, fn any) error {
+	if !isIdentifier(name) {
+		return fmt.Errorf("%w: %q is not a valid identifier", ErrInvalidTemplateFunction, name)
+	}
+
+	fnType := reflect.TypeOf(fn)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return fmt.Errorf("%w: %s is not a function", ErrInvalidTemplateFunction, name)
+	}
+
+	switch {
+	case fnType.NumOut() == 1:
+		return nil
+	case fnType.NumOut() == 2 && fnType.Out(1) == errorType:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s must return a value, or a value and an error", ErrInvalidTemplateFunction, name)
+	}
+}
+
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case r == '_':
+		case i == 0 && !unicode.IsLetter(r):
+			return false
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,144 @@
+package gotmpl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestRegisterFuncs(t *testing.T) {
+	err := RegisterFuncs(template.FuncMap{
+		"testRegisteredGreeting": func(name string) string {
+			return "Hello " + name
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	t.Run("registered functions are available", func(t *testing.T) {
+		for _, contentType := range []string{"text/plain", "text/html"} {
+			transformer, err := NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{
+				ContentType: contentType,
+				Template:    `{{ testRegisteredGreeting .name }}`,
+			})
+			if err != nil {
+				t.Fatalf("failed to create transformer: %v", err)
+			}
+
+			result, err := transformer.Transform(map[string]any{"name": "John"})
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if result != "Hello John" {
+				t.Errorf("%s: expected result to be %q, got: %q", contentType, "Hello John", result)
+			}
+		}
+	})
+
+	t.Run("duplicated registration", func(t *testing.T) {
+		err := RegisterFuncs(template.FuncMap{
+			"testRegisteredGreeting": strings.ToUpper,
+		})
+		if !errors.Is(err, ErrTemplateFunctionConflict) {
+			t.Fatalf("expected ErrTemplateFunctionConflict, got: %v", err)
+		}
+	})
+
+	t.Run("conflict with sprig", func(t *testing.T) {
+		err := RegisterFuncs(template.FuncMap{
+			"upper": strings.ToUpper,
+		})
+		if !errors.Is(err, ErrTemplateFunctionConflict) {
+			t.Fatalf("expected ErrTemplateFunctionConflict, got: %v", err)
+		}
+	})
+
+	t.Run("failed registration is not applied partially", func(t *testing.T) {
+		err := RegisterFuncs(template.FuncMap{
+			"testPartialRegistration": strings.ToUpper,
+			"lower":                   strings.ToLower,
+		})
+		if !errors.Is(err, ErrTemplateFunctionConflict) {
+			t.Fatalf("expected ErrTemplateFunctionConflict, got: %v", err)
+		}
+
+		_, err = NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{
+			Template: `{{ testPartialRegistration "a" }}`,
+		})
+		if err == nil {
+			t.Fatal("expected error for the undefined function, got nil")
+		}
+	})
+}
+
+func TestWithFuncs(t *testing.T) {
+	t.Run("custom functions", func(t *testing.T) {
+		transformer, err := NewGoTemplateTransformer(
+			"test",
+			&GoTemplateTransformerConfig{
+				ContentType: "application/json",
+				Template:    `{"amount": "{{ money .amount }}"}`,
+			},
+			WithFuncs(template.FuncMap{
+				"money": func(value float64) string {
+					return fmt.Sprintf("$%.2f", value)
+				},
+			}),
+		)
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		result, err := transformer.Transform(map[string]any{"amount": 12.5})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string]any{"amount": "$12.50"}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("conflicting functions", func(t *testing.T) {
+		for name, funcs := range map[string][]template.FuncMap{
+			"sprig": {
+				{"now": strings.ToUpper},
+			},
+			"another option": {
+				{"mask": strings.ToUpper},
+				{"mask": strings.ToLower},
+			},
+		} {
+			options := []GoTemplateTransformerOption{}
+
+			for _, funcMap := range funcs {
+				options = append(options, WithFuncs(funcMap))
+			}
+
+			_, err := NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{Template: "hello"}, options...)
+			if !errors.Is(err, ErrTemplateFunctionConflict) {
+				t.Errorf("%s: expected ErrTemplateFunctionConflict, got: %v", name, err)
+			}
+		}
+	})
+
+	t.Run("invalid functions", func(t *testing.T) {
+		for name, funcMap := range map[string]template.FuncMap{
+			"invalid name":       {"mask-id": strings.ToUpper},
+			"not a function":     {"mask": "value"},
+			"no return value":    {"mask": func() {}},
+			"invalid error type": {"mask": func() (string, string) { return "", "" }},
+		} {
+			_, err := NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{Template: "hello"}, WithFuncs(funcMap))
+			if !errors.Is(err, ErrInvalidTemplateFunction) {
+				t.Errorf("%s: expected ErrInvalidTemplateFunction, got: %v", name, err)
+			}
+		}
+	})
+}
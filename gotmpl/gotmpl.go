@@ -7,14 +7,27 @@ import (
 	"fmt"
 	htmltemplate "html/template"
 	"io"
+	"maps"
+	"slices"
 	"strings"
 	"text/template"
+	"text/template/parse"
 
-	"github.com/Masterminds/sprig/v3"
 	"github.com/relychan/gotransform/transformtypes"
+	"github.com/relychan/gotransform/xmlmap"
+	"go.yaml.in/yaml/v4"
 )
 
-const contentTypeHTML = "text/html"
+const (
+	contentTypeHTML  = "text/html"
+	contentTypeJSON  = "application/json"
+	contentTypeYAML  = "application/yaml"
+	contentTypeXYAML = "application/x-yaml"
+	contentTypeXML   = "application/xml"
+	contentTypeTXML  = "text/xml"
+	contentTypeForm  = "application/x-www-form-urlencoded"
+	contentTypeCSV   = "text/csv"
+)
 
 // Template abstracts the interface for both text and html template implementation.
 type Template interface {
@@ -24,24 +37,54 @@ type Template interface {
 // GoTemplateTransformer implements the template transformer using Go template.
 type GoTemplateTransformer struct {
 	contentType string
+	decodeXML   bool
+	csvHeader   bool
 	template    Template
+	limiter     *executionLimiter
 }
 
 // NewGoTemplateTransformer creates a new GoTemplateTransformer instance.
 func NewGoTemplateTransformer(
 	name string,
 	config *GoTemplateTransformerConfig,
+	options ...GoTemplateTransformerOption,
 ) (*GoTemplateTransformer, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid config of template %q: %w", name, err)
+	}
+
+	opts := &goTemplateTransformerOptions{}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	funcMap, err := opts.funcMap(config.Functions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid functions of template %q: %w", name, err)
+	}
+
+	limiter, err := config.evaluateLimits()
+	if err != nil {
+		return nil, fmt.Errorf("invalid limits of template %q: %w", name, err)
+	}
+
+	if limiter != nil {
+		limiter.wrapFuncs(funcMap)
+	}
+
 	result := &GoTemplateTransformer{
 		contentType: config.ContentType,
+		decodeXML:   config.DecodeXML,
+		csvHeader:   config.CSVHeader,
+		limiter:     limiter,
 	}
 
-	var err error
-
 	if strings.HasPrefix(config.ContentType, contentTypeHTML) {
-		result.template, err = htmltemplate.New(name).Funcs(sprig.FuncMap()).Parse(config.Template)
+		result.template, err = parseHTMLTemplate(name, config, funcMap, opts.files(), limiter)
 	} else {
-		result.template, err = template.New(name).Funcs(sprig.FuncMap()).Parse(config.Template)
+		result.template, err = parseTextTemplate(name, config, funcMap, opts.files(), limiter)
 	}
 
 	if err != nil {
@@ -51,6 +94,175 @@ func NewGoTemplateTransformer(
 	return result, nil
 }
 
+func parseHTMLTemplate(
+	name string,
+	config *GoTemplateTransformerConfig,
+	funcMap template.FuncMap,
+	files templateFiles,
+	limiter *executionLimiter,
+) (Template, error) { //nolint:ireturn
+	left, right := config.delims()
+
+	return parseLimitedTemplateSet(
+		htmltemplate.New(name).Delims(left, right).Option(config.parseOptions()...).Funcs(funcMap),
+		name,
+		config,
+		files,
+		limiter,
+		func(tmpl *htmltemplate.Template) *parse.Tree {
+			return tmpl.Tree
+		},
+	)
+}
+
+func parseTextTemplate(
+	name string,
+	config *GoTemplateTransformerConfig,
+	funcMap template.FuncMap,
+	files templateFiles,
+	limiter *executionLimiter,
+) (Template, error) { //nolint:ireturn
+	left, right := config.delims()
+
+	return parseLimitedTemplateSet(
+		template.New(name).Delims(left, right).Option(config.parseOptions()...).Funcs(funcMap),
+		name,
+		config,
+		files,
+		limiter,
+		func(tmpl *template.Template) *parse.Tree {
+			return tmpl.Tree
+		},
+	)
+}
+
+// limitedTemplateSet abstracts a text or html template set which can be parsed and instrumented for resource limits.
+type limitedTemplateSet[T any] interface {
+	templateSet[T]
+	cloneableTemplateSet[T]
+
+	Name() string
+	Templates() []T
+}
+
+// parseLimitedTemplateSet parses the template set and instruments range actions of all templates
+// if the limiter tracks iterations. The tree function returns the parse tree of a template of the set.
+func parseLimitedTemplateSet[T limitedTemplateSet[T]](
+	root T,
+	name string,
+	config *GoTemplateTransformerConfig,
+	files templateFiles,
+	limiter *executionLimiter,
+	tree func(tmpl T) *parse.Tree,
+) (Template, error) { //nolint:ireturn
+	set, entrypoint, err := parseTemplateSet(root, name, config, files)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := set.Templates()
+
+	if !slices.ContainsFunc(templates, func(tmpl T) bool {
+		return tmpl.Name() == entrypoint
+	}) {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateEntrypointNotFound, entrypoint)
+	}
+
+	if limiter != nil && limiter.tracksIterations() {
+		trees := make([]*parse.Tree, len(templates))
+
+		for i, tmpl := range templates {
+			trees[i] = tree(tmpl)
+		}
+
+		err := instrumentTrees(trees)
+		if err != nil {
+			return nil, err
+		}
+
+		limiter.clone = newTemplateCloner(set, name, entrypoint)
+	}
+
+	return newEntrypointTemplate(set, name, entrypoint), nil
+}
+
+// templateSet abstracts the parser of both text and html template sets.
+type templateSet[T any] interface {
+	New(name string) T
+	Parse(text string) (T, error)
+}
+
+// parseTemplateSet parses partial libraries, template files, named templates and the main template
+// into the same template set, and returns the name of the entrypoint template.
+// Named templates override templates of partial libraries with the same name.
+func parseTemplateSet[T templateSet[T]](
+	root T,
+	name string,
+	config *GoTemplateTransformerConfig,
+	files templateFiles,
+) (T, string, error) {
+	entrypoint := config.Entrypoint
+
+	for _, pattern := range config.Partials {
+		_, err := parseGlob(files, root, pattern, false, ErrTemplatePartialNotFound)
+		if err != nil {
+			return root, "", err
+		}
+	}
+
+	if config.TemplateFile != "" {
+		names, err := parseGlob(files, root, config.TemplateFile, true, ErrTemplateFileNotFound)
+		if err != nil {
+			return root, "", err
+		}
+
+		if entrypoint == "" {
+			entrypoint = names[0]
+		}
+	}
+
+	if entrypoint == "" {
+		entrypoint = name
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(config.Templates)) {
+		_, err := root.New(key).Parse(config.Templates[key])
+		if err != nil {
+			return root, "", err
+		}
+	}
+
+	set, err := root.Parse(config.Template)
+
+	return set, entrypoint, err
+}
+
+// executableTemplateSet abstracts a template set which can execute a named template.
+type executableTemplateSet interface {
+	Template
+	ExecuteTemplate(wr io.Writer, name string, data any) error
+}
+
+// entrypointTemplate executes the entrypoint template of a template set.
+type entrypointTemplate struct {
+	set  executableTemplateSet
+	name string
+}
+
+// newEntrypointTemplate returns the template set itself if the entrypoint is the root template.
+func newEntrypointTemplate(set executableTemplateSet, rootName, entrypoint string) Template { //nolint:ireturn
+	if entrypoint == rootName {
+		return set
+	}
+
+	return entrypointTemplate{set: set, name: entrypoint}
+}
+
+// Execute applies the entrypoint template to the data object.
+func (et entrypointTemplate) Execute(wr io.Writer, data any) error {
+	return et.set.ExecuteTemplate(wr, et.name, data)
+}
+
 // Type returns the transform template type of this instance.
 func (GoTemplateTransformer) Type() transformtypes.TransformTemplateType {
 	return transformtypes.TransformTemplateGo
@@ -58,12 +270,15 @@ func (GoTemplateTransformer) Type() transformtypes.TransformTemplateType {
 
 // IsZero checks if the transformer is zero-valued.
 func (gtt GoTemplateTransformer) IsZero() bool {
-	return gtt.contentType == "" && gtt.template == nil
+	return gtt.contentType == "" && !gtt.decodeXML && !gtt.csvHeader && gtt.template == nil
 }
 
 // Equal checks if this instance equals the target value.
 func (gtt GoTemplateTransformer) Equal(target GoTemplateTransformer) bool {
 	return gtt.contentType == target.contentType &&
+		gtt.decodeXML == target.decodeXML &&
+		gtt.csvHeader == target.csvHeader &&
+		gtt.limiter == target.limiter &&
 		gtt.template == target.template
 }
 
@@ -71,13 +286,20 @@ func (gtt GoTemplateTransformer) Equal(target GoTemplateTransformer) bool {
 func (gtt GoTemplateTransformer) Transform(data any) (any, error) {
 	var buffer bytes.Buffer
 
-	err := gtt.template.Execute(&buffer, data)
+	var err error
+
+	if gtt.limiter != nil {
+		err = gtt.limiter.execute(gtt.template, &buffer, data)
+	} else {
+		err = gtt.template.Execute(&buffer, data)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute template: %w", err)
 	}
 
 	switch gtt.contentType {
-	case "application/json":
+	case contentTypeJSON:
 		var result any
 
 		err := json.Unmarshal(buffer.Bytes(), &result)
@@ -86,6 +308,35 @@ func (gtt GoTemplateTransformer) Transform(data any) (any, error) {
 		}
 
 		return result, nil
+	case contentTypeYAML, contentTypeXYAML:
+		var result any
+
+		err := yaml.Unmarshal(buffer.Bytes(), &result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal YAML result: %w", err)
+		}
+
+		return normalizeYAMLValue(result), nil
+	case contentTypeXML, contentTypeTXML:
+		if gtt.decodeXML {
+			result, err := xmlmap.Unmarshal(buffer.Bytes())
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode XML result: %w", err)
+			}
+
+			return result, nil
+		}
+
+		err := xmlmap.Validate(buffer.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML result: %w", err)
+		}
+
+		return buffer.String(), nil
+	case contentTypeForm:
+		return decodeFormOutput(buffer.Bytes())
+	case contentTypeCSV:
+		return decodeCSVOutput(buffer.Bytes(), gtt.csvHeader)
 	default:
 		return buffer.String(), nil
 	}
@@ -1,7 +1,10 @@
 package gotmpl
 
 import (
+	"errors"
+	"reflect"
 	"testing"
+	"testing/fstest"
 )
 
 func TestNewGoTemplateTransformer(t *testing.T) {
@@ -76,6 +79,19 @@ func TestNewGoTemplateTransformer(t *testing.T) {
 			t.Errorf("expected transformer to be nil, got: %v", transformer)
 		}
 	})
+
+	t.Run("error with invalid config", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType:  "text/plain",
+			Template:     `{{.name}}`,
+			TemplateFile: "template.tmpl",
+		}
+
+		_, err := NewGoTemplateTransformer("test", config)
+		if !errors.Is(err, ErrTemplateSourceConflict) {
+			t.Fatalf("expected ErrTemplateSourceConflict, got: %v", err)
+		}
+	})
 }
 
 func TestGoTemplateTransformer_Transform(t *testing.T) {
@@ -176,6 +192,474 @@ func TestGoTemplateTransformer_Transform(t *testing.T) {
 		}
 	})
 
+	t.Run("transform with YAML output", func(t *testing.T) {
+		for _, contentType := range []string{"application/yaml", "application/x-yaml"} {
+			config := &GoTemplateTransformerConfig{
+				ContentType: contentType,
+				Template: `message: {{.name}}
+tags:
+{{- range .tags }}
+  - {{ . }}
+{{- end }}
+codes:
+  1: one
+  true: yes`,
+			}
+
+			transformer, err := NewGoTemplateTransformer("test", config)
+			if err != nil {
+				t.Fatalf("failed to create transformer: %v", err)
+			}
+
+			data := map[string]any{"name": "John", "tags": []string{"a", "b"}}
+			result, err := transformer.Transform(data)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			expected := map[string]any{
+				"message": "John",
+				"tags":    []any{"a", "b"},
+				"codes":   map[string]any{"1": "one", "true": "yes"},
+			}
+			if !reflect.DeepEqual(expected, result) {
+				t.Errorf("%s: expected %v, got: %v", contentType, expected, result)
+			}
+		}
+	})
+
+	t.Run("error with invalid YAML output", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType: "application/yaml",
+			Template:    `message: [unclosed`,
+		}
+
+		transformer, err := NewGoTemplateTransformer("test", config)
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		_, err = transformer.Transform(map[string]any{})
+		if err == nil {
+			t.Fatal("expected error for invalid YAML, got nil")
+		}
+	})
+
+	t.Run("transform with XML output", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType: "application/xml",
+			Template:    `<user id="{{.id}}"><name>{{.name}}</name></user>`,
+		}
+
+		transformer, err := NewGoTemplateTransformer("test", config)
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		data := map[string]any{"id": 1, "name": "John"}
+		result, err := transformer.Transform(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := `<user id="1"><name>John</name></user>`
+		if result != expected {
+			t.Errorf("expected result to be %q, got: %q", expected, result)
+		}
+	})
+
+	t.Run("transform with decoded XML output", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType: "text/xml",
+			Template:    `<user id="{{.id}}"><name>{{.name}}</name></user>`,
+			DecodeXML:   true,
+		}
+
+		transformer, err := NewGoTemplateTransformer("test", config)
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		data := map[string]any{"id": 1, "name": "John"}
+		result, err := transformer.Transform(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string]any{
+			"user": map[string]any{"@id": "1", "name": "John"},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("error with malformed XML output", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType: "application/xml",
+			Template:    `<user><name>{{.name}}</user>`,
+		}
+
+		transformer, err := NewGoTemplateTransformer("test", config)
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		_, err = transformer.Transform(map[string]any{"name": "John"})
+		if err == nil {
+			t.Fatal("expected error for malformed XML, got nil")
+		}
+	})
+
+	t.Run("transform with form-urlencoded output", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType: "application/x-www-form-urlencoded",
+			Template: `name={{ .name | urlquery }}&tag=a&tag=b
+`,
+		}
+
+		transformer, err := NewGoTemplateTransformer("test", config)
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		result, err := transformer.Transform(map[string]any{"name": "John Doe"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string][]string{
+			"name": {"John Doe"},
+			"tag":  {"a", "b"},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("error with malformed form-urlencoded output", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType: "application/x-www-form-urlencoded",
+			Template:    `name=%zz`,
+		}
+
+		transformer, err := NewGoTemplateTransformer("test", config)
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		_, err = transformer.Transform(map[string]any{})
+		if err == nil {
+			t.Fatal("expected error for malformed form-urlencoded output, got nil")
+		}
+	})
+
+	t.Run("transform with CSV output", func(t *testing.T) {
+		template := `id,name
+{{- range .users }}
+{{ .id }},"{{ .name }}"
+{{- end }}
+`
+		data := map[string]any{
+			"users": []any{
+				map[string]any{"id": 1, "name": "Doe, John"},
+				map[string]any{"id": 2, "name": "Ada"},
+			},
+		}
+
+		transformer, err := NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{
+			ContentType: "text/csv",
+			Template:    template,
+		})
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		result, err := transformer.Transform(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expectedRows := [][]string{
+			{"id", "name"},
+			{"1", "Doe, John"},
+			{"2", "Ada"},
+		}
+		if !reflect.DeepEqual(expectedRows, result) {
+			t.Errorf("expected %v, got: %v", expectedRows, result)
+		}
+
+		transformer, err = NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{
+			ContentType: "text/csv",
+			Template:    template,
+			CSVHeader:   true,
+		})
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		result, err = transformer.Transform(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expectedRecords := []map[string]string{
+			{"id": "1", "name": "Doe, John"},
+			{"id": "2", "name": "Ada"},
+		}
+		if !reflect.DeepEqual(expectedRecords, result) {
+			t.Errorf("expected %v, got: %v", expectedRecords, result)
+		}
+	})
+
+	t.Run("error with malformed CSV output", func(t *testing.T) {
+		for name, config := range map[string]*GoTemplateTransformerConfig{
+			"inconsistent columns": {
+				ContentType: "text/csv",
+				Template:    "a,b\n1\n",
+			},
+			"duplicated header": {
+				ContentType: "text/csv",
+				Template:    "a,a\n1,2\n",
+				CSVHeader:   true,
+			},
+		} {
+			transformer, err := NewGoTemplateTransformer("test", config)
+			if err != nil {
+				t.Fatalf("failed to create transformer: %v", err)
+			}
+
+			_, err = transformer.Transform(map[string]any{})
+			if err == nil {
+				t.Fatalf("%s: expected error for malformed CSV output, got nil", name)
+			}
+		}
+	})
+
+	t.Run("transform with custom delimiters", func(t *testing.T) {
+		for _, contentType := range []string{"text/plain", "text/html"} {
+			config := &GoTemplateTransformerConfig{
+				ContentType: contentType,
+				Template:    `image: {{ .Values.image }} [[ .name | upper ]]`,
+				Delims:      &GoTemplateDelims{Left: "[[", Right: "]]"},
+			}
+
+			transformer, err := NewGoTemplateTransformer("test", config)
+			if err != nil {
+				t.Fatalf("failed to create transformer: %v", err)
+			}
+
+			result, err := transformer.Transform(map[string]any{"name": "nginx"})
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			expected := "image: {{ .Values.image }} NGINX"
+			if result != expected {
+				t.Errorf("%s: expected result to be %q, got: %q", contentType, expected, result)
+			}
+		}
+	})
+
+	t.Run("missing key options", func(t *testing.T) {
+		for missingKey, expected := range map[MissingKey]string{
+			"":                "<no value>",
+			MissingKeyDefault: "<no value>",
+			MissingKeyZero:    "<no value>",
+			MissingKeyError:   "",
+		} {
+			config := &GoTemplateTransformerConfig{
+				ContentType: "text/plain",
+				Template:    `{{ .name }}`,
+				MissingKey:  missingKey,
+			}
+
+			transformer, err := NewGoTemplateTransformer("test", config)
+			if err != nil {
+				t.Fatalf("failed to create transformer: %v", err)
+			}
+
+			result, err := transformer.Transform(map[string]any{})
+			if missingKey == MissingKeyError {
+				if err == nil {
+					t.Fatal("expected error for missing key, got nil")
+				}
+
+				continue
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if result != expected {
+				t.Errorf("%s: expected result to be %q, got: %q", missingKey, expected, result)
+			}
+		}
+	})
+
+	t.Run("missing key error with html template", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType: "text/html",
+			Template:    `<p>{{ .name }}</p>`,
+			MissingKey:  MissingKeyError,
+		}
+
+		transformer, err := NewGoTemplateTransformer("test", config)
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		_, err = transformer.Transform(map[string]any{})
+		if err == nil {
+			t.Fatal("expected error for missing key, got nil")
+		}
+	})
+
+	t.Run("transform with named templates", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType: "application/json",
+			Template:    `{"message": "{{ template "greeting" . }}"}`,
+			Templates: map[string]string{
+				"greeting": `Hello {{ template "name" . }}`,
+				"name":     `{{ .name }}`,
+			},
+		}
+
+		transformer, err := NewGoTemplateTransformer("test", config)
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		result, err := transformer.Transform(map[string]any{"name": "John"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string]any{"message": "Hello John"}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("transform with entrypoint and partials", func(t *testing.T) {
+		for _, contentType := range []string{"text/plain", "text/html"} {
+			config := &GoTemplateTransformerConfig{
+				ContentType: contentType,
+				Templates: map[string]string{
+					"email":  `{{ template "header" . }} {{ template "shout" .message }} {{ template "footer" . }}`,
+					"footer": `Bye`,
+				},
+				Entrypoint: "email",
+				Partials:   []string{"testdata/partials/*.tmpl"},
+			}
+
+			transformer, err := NewGoTemplateTransformer("test", config)
+			if err != nil {
+				t.Fatalf("failed to create transformer: %v", err)
+			}
+
+			result, err := transformer.Transform(map[string]any{"name": "John", "message": "hi"})
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			expected := "Dear John, HI! Bye"
+			if result != expected {
+				t.Errorf("%s: expected result to be %q, got: %q", contentType, expected, result)
+			}
+		}
+	})
+
+	t.Run("error with unknown entrypoint", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType: "text/plain",
+			Templates:   map[string]string{"email": `hello`},
+			Entrypoint:  "unknown",
+		}
+
+		_, err := NewGoTemplateTransformer("test", config)
+		if !errors.Is(err, ErrTemplateEntrypointNotFound) {
+			t.Fatalf("expected ErrTemplateEntrypointNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("error with missing partials", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType: "text/plain",
+			Template:    `hello`,
+			Partials:    []string{"testdata/unknown/*.tmpl"},
+		}
+
+		_, err := NewGoTemplateTransformer("test", config)
+		if !errors.Is(err, ErrTemplatePartialNotFound) {
+			t.Fatalf("expected ErrTemplatePartialNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("transform with template file", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType:  "text/html",
+			TemplateFile: "templates/*.html",
+			Partials:     []string{"partials/layout.tmpl"},
+		}
+
+		transformer, err := NewGoTemplateTransformer("test", config, WithBaseDir("testdata"))
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		result, err := transformer.Transform(map[string]any{"name": "<John>"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := "<p>Dear &lt;John&gt;,</p>\n"
+		if result != expected {
+			t.Errorf("expected result to be %q, got: %q", expected, result)
+		}
+	})
+
+	t.Run("transform with template files of a file system", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType:  "text/plain",
+			TemplateFile: "mail/*.txt",
+			Entrypoint:   "body.txt",
+		}
+
+		fsys := fstest.MapFS{
+			"mail/body.txt":    {Data: []byte(`{{ template "subject.txt" . }}: {{ .text }}`)},
+			"mail/subject.txt": {Data: []byte(`Hi {{ .name }}`)},
+		}
+
+		transformer, err := NewGoTemplateTransformer("test", config, WithFileSystem(fsys))
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		result, err := transformer.Transform(map[string]any{"name": "John", "text": "welcome"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := "Hi John: welcome"
+		if result != expected {
+			t.Errorf("expected result to be %q, got: %q", expected, result)
+		}
+	})
+
+	t.Run("error with missing template file", func(t *testing.T) {
+		config := &GoTemplateTransformerConfig{
+			ContentType:  "text/plain",
+			TemplateFile: "unknown.txt",
+		}
+
+		_, err := NewGoTemplateTransformer("test", config, WithFileSystem(fstest.MapFS{}))
+		if !errors.Is(err, ErrTemplateFileNotFound) {
+			t.Fatalf("expected ErrTemplateFileNotFound, got: %v", err)
+		}
+	})
+
 	t.Run("error with invalid JSON output", func(t *testing.T) {
 		config := &GoTemplateTransformerConfig{
 			ContentType: "application/json",
@@ -0,0 +1,388 @@
+package gotmpl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+var (
+	// ErrLimitExceeded occurs when the execution of the template exceeds a resource limit.
+	ErrLimitExceeded = errors.New("template resource limit exceeded")
+	// ErrInvalidLimits occurs when the config of resource limits is invalid.
+	ErrInvalidLimits = errors.New("invalid template resource limits")
+)
+
+// iterationFuncName is the name of the function which is called at the start of every range iteration
+// with the location of the range action in the template.
+const iterationFuncName = "_gotransformIteration"
+
+// GoTemplateLimits represents resource limits of the template execution. Zero values are unlimited.
+type GoTemplateLimits struct {
+	// MaxOutputBytes is the maximum size of the rendered output in bytes.
+	// The size is checked whenever the template writes output, so values which functions build in memory,
+	// e.g. with printf or join, are not limited until they are written. The repeat function fails early
+	// if its result exceeds the limit.
+	MaxOutputBytes int `json:"maxOutputBytes,omitempty" yaml:"maxOutputBytes,omitempty"`
+	// MaxExecutionTime is the maximum duration of the execution, e.g. 500ms or 2s.
+	// The deadline is checked whenever the template writes output or iterates a range.
+	MaxExecutionTime string `json:"maxExecutionTime,omitempty" yaml:"maxExecutionTime,omitempty"`
+	// MaxIterations is the maximum number of iterations of all range actions in total.
+	// The until and untilStep functions also fail if the generated list is longer than the limit.
+	MaxIterations int `json:"maxIterations,omitempty" yaml:"maxIterations,omitempty"`
+}
+
+// IsZero checks if the limits are empty.
+func (gl GoTemplateLimits) IsZero() bool {
+	return gl.MaxOutputBytes == 0 && gl.MaxExecutionTime == "" && gl.MaxIterations == 0
+}
+
+// Equal checks if this instance equals the target value.
+func (gl GoTemplateLimits) Equal(target GoTemplateLimits) bool {
+	return gl == target
+}
+
+// Validate checks if the limits are valid.
+func (gl GoTemplateLimits) Validate() error {
+	_, err := gl.evaluate()
+
+	return err
+}
+
+func (gl GoTemplateLimits) evaluate() (*executionLimiter, error) {
+	if gl.MaxOutputBytes < 0 {
+		return nil, fmt.Errorf("%w: maxOutputBytes must not be negative", ErrInvalidLimits)
+	}
+
+	if gl.MaxIterations < 0 {
+		return nil, fmt.Errorf("%w: maxIterations must not be negative", ErrInvalidLimits)
+	}
+
+	result := &executionLimiter{
+		maxOutputBytes: gl.MaxOutputBytes,
+		maxIterations:  gl.MaxIterations,
+	}
+
+	if gl.MaxExecutionTime != "" {
+		duration, err := time.ParseDuration(gl.MaxExecutionTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: maxExecutionTime: %w", ErrInvalidLimits, err)
+		}
+
+		if duration < 0 {
+			return nil, fmt.Errorf("%w: maxExecutionTime must not be negative", ErrInvalidLimits)
+		}
+
+		result.maxExecutionTime = duration
+	}
+
+	return result, nil
+}
+
+func (gl GoTemplateLimits) toMap() map[string]any {
+	result := map[string]any{}
+
+	if gl.MaxOutputBytes > 0 {
+		result["maxOutputBytes"] = gl.MaxOutputBytes
+	}
+
+	if gl.MaxExecutionTime != "" {
+		result["maxExecutionTime"] = gl.MaxExecutionTime
+	}
+
+	if gl.MaxIterations > 0 {
+		result["maxIterations"] = gl.MaxIterations
+	}
+
+	return result
+}
+
+// executionLimiter enforces resource limits on executions of a template.
+type executionLimiter struct {
+	maxOutputBytes   int
+	maxExecutionTime time.Duration
+	maxIterations    int
+	// clone creates a copy of the template with the iteration function of an execution.
+	// It is nil if range iterations are not tracked.
+	clone func(funcs template.FuncMap) (Template, error)
+}
+
+// wrapFuncs replaces sprig functions which build values in memory with variants which fail with [ErrLimitExceeded]
+// if the result exceeds the limits, because the limits are otherwise checked only on writes and range iterations.
+// Functions which are disabled by the function policy are not added.
+func (el *executionLimiter) wrapFuncs(funcMap template.FuncMap) {
+	if _, ok := funcMap["repeat"]; ok && el.maxOutputBytes > 0 {
+		funcMap["repeat"] = el.repeat
+	}
+
+	if el.maxIterations <= 0 {
+		return
+	}
+
+	if _, ok := funcMap["until"]; ok {
+		funcMap["until"] = el.until
+	}
+
+	if _, ok := funcMap["untilStep"]; ok {
+		funcMap["untilStep"] = el.untilStep
+	}
+}
+
+func (el *executionLimiter) repeat(count int, str string) (string, error) {
+	if str != "" && count > el.maxOutputBytes/len(str) {
+		return "", fmt.Errorf("%w: result of repeat exceeds %d bytes", ErrLimitExceeded, el.maxOutputBytes)
+	}
+
+	return strings.Repeat(str, max(count, 0)), nil
+}
+
+func (el *executionLimiter) until(count int) ([]int, error) {
+	step := 1
+	if count < 0 {
+		step = -1
+	}
+
+	return el.untilStep(0, count, step)
+}
+
+func (el *executionLimiter) untilStep(start, stop, step int) ([]int, error) {
+	if untilStepLength(start, stop, step) > uint64(el.maxIterations) {
+		return nil, fmt.Errorf("%w: list of untilStep exceeds %d items", ErrLimitExceeded, el.maxIterations)
+	}
+
+	return sprigUntilStep(start, stop, step), nil
+}
+
+// sprigUntilStep is the untilStep function of sprig.
+var sprigUntilStep, _ = sprig.GenericFuncMap()["untilStep"].(func(start, stop, step int) []int)
+
+// untilStepLength returns the number of items which untilStep generates.
+func untilStepLength(start, stop, step int) uint64 {
+	var distance, stride uint64
+
+	switch {
+	case stop < start && step < 0:
+		distance, stride = uint64(start)-uint64(stop), uint64(-step)
+	case stop > start && step > 0:
+		distance, stride = uint64(stop)-uint64(start), uint64(step)
+	default:
+		return 0
+	}
+
+	length := distance / stride
+	if distance%stride != 0 {
+		length++
+	}
+
+	return length
+}
+
+// tracksIterations checks if range iterations must be tracked to enforce the limits.
+func (el *executionLimiter) tracksIterations() bool {
+	return el.maxIterations > 0 || el.maxExecutionTime > 0
+}
+
+// execute applies the template to the data object within the limits.
+func (el *executionLimiter) execute(tmpl Template, wr io.Writer, data any) error {
+	state := &executionState{
+		limiter: el,
+		writer:  wr,
+	}
+
+	if el.maxExecutionTime > 0 {
+		state.deadline = time.Now().Add(el.maxExecutionTime)
+	}
+
+	if el.clone != nil {
+		var err error
+
+		tmpl, err = el.clone(template.FuncMap{
+			iterationFuncName: state.iterate,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := tmpl.Execute(state, data)
+	if state.err != nil {
+		// the error of the iteration function refers to the location of the user's range action
+		// instead of the injected function call.
+		return state.err
+	}
+
+	return err
+}
+
+// executionState counts resources of an execution.
+type executionState struct {
+	limiter    *executionLimiter
+	writer     io.Writer
+	deadline   time.Time
+	written    int
+	iterations int
+	// err is the error of the iteration function which stopped the execution.
+	err error
+}
+
+// Write implements the io.Writer interface.
+func (es *executionState) Write(p []byte) (int, error) {
+	err := es.checkDeadline()
+	if err != nil {
+		return 0, err
+	}
+
+	if es.limiter.maxOutputBytes > 0 && es.written+len(p) > es.limiter.maxOutputBytes {
+		return 0, fmt.Errorf("%w: output exceeds %d bytes", ErrLimitExceeded, es.limiter.maxOutputBytes)
+	}
+
+	n, err := es.writer.Write(p)
+	es.written += n
+
+	return n, err
+}
+
+// iterate is called at the start of every range iteration. It always returns false to render nothing.
+func (es *executionState) iterate(location string) (bool, error) {
+	es.iterations++
+
+	err := es.checkIteration()
+	if err != nil {
+		es.err = fmt.Errorf("template: %s: %w", location, err)
+
+		return false, es.err
+	}
+
+	return false, nil
+}
+
+func (es *executionState) checkIteration() error {
+	if es.limiter.maxIterations > 0 && es.iterations > es.limiter.maxIterations {
+		return fmt.Errorf("%w: iterations exceed %d", ErrLimitExceeded, es.limiter.maxIterations)
+	}
+
+	return es.checkDeadline()
+}
+
+func (es *executionState) checkDeadline() error {
+	if !es.deadline.IsZero() && time.Now().After(es.deadline) {
+		return fmt.Errorf("%w: execution exceeds %s", ErrLimitExceeded, es.limiter.maxExecutionTime)
+	}
+
+	return nil
+}
+
+// cloneableTemplateSet abstracts a text or html template set which can be cloned with other functions.
+type cloneableTemplateSet[T any] interface {
+	executableTemplateSet
+
+	Clone() (T, error)
+	Funcs(funcMap template.FuncMap) T
+}
+
+// newTemplateCloner returns a function which clones the template set with functions of an execution.
+// Html templates must not be executed before they are cloned, so the template set is kept as the prototype.
+// Html templates are escaped on the first execution, so every execution of a clone escapes the templates again.
+// The cost is proportional to the size of the templates, not of the data, and only applies if iterations are tracked.
+func newTemplateCloner[T cloneableTemplateSet[T]](
+	set T,
+	rootName, entrypoint string,
+) func(template.FuncMap) (Template, error) {
+	return func(funcs template.FuncMap) (Template, error) {
+		clone, err := set.Clone()
+		if err != nil {
+			return nil, fmt.Errorf("failed to clone template: %w", err)
+		}
+
+		return newEntrypointTemplate(clone.Funcs(funcs), rootName, entrypoint), nil
+	}
+}
+
+// instrumentTrees inserts a call of the iteration function at the start of every range body of the trees.
+// The call is the condition of an empty if action, which renders nothing and is not altered by html escaping.
+func instrumentTrees(trees []*parse.Tree) error {
+	visited := map[*parse.Tree]bool{}
+
+	for _, tree := range trees {
+		if tree == nil || tree.Root == nil || visited[tree] {
+			continue
+		}
+
+		visited[tree] = true
+
+		err := instrumentNode(tree, tree.Root)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func instrumentNode(tree *parse.Tree, node parse.Node) error {
+	switch typedNode := node.(type) {
+	case *parse.ListNode:
+		if typedNode == nil {
+			return nil
+		}
+
+		for _, child := range typedNode.Nodes {
+			err := instrumentNode(tree, child)
+			if err != nil {
+				return err
+			}
+		}
+	case *parse.IfNode:
+		return instrumentBranch(tree, &typedNode.BranchNode)
+	case *parse.WithNode:
+		return instrumentBranch(tree, &typedNode.BranchNode)
+	case *parse.RangeNode:
+		err := instrumentBranch(tree, &typedNode.BranchNode)
+		if err != nil {
+			return err
+		}
+
+		location, _ := tree.ErrorContext(typedNode)
+
+		iterationNode, err := newIterationNode(location)
+		if err != nil {
+			return err
+		}
+
+		typedNode.List.Nodes = append([]parse.Node{iterationNode}, typedNode.List.Nodes...)
+	}
+
+	return nil
+}
+
+func instrumentBranch(tree *parse.Tree, node *parse.BranchNode) error {
+	err := instrumentNode(tree, node.List)
+	if err != nil {
+		return err
+	}
+
+	return instrumentNode(tree, node.ElseList)
+}
+
+// newIterationNode parses a new node which calls the iteration function with the location of the range action.
+func newIterationNode(location string) (parse.Node, error) {
+	trees, err := parse.Parse(
+		iterationFuncName,
+		"{{if "+iterationFuncName+" "+strconv.Quote(location)+"}}{{end}}",
+		"{{",
+		"}}",
+		map[string]any{iterationFuncName: func(string) bool { return false }},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return trees[iterationFuncName].Root.Nodes[0], nil
+}
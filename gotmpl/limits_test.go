@@ -0,0 +1,233 @@
+package gotmpl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGoTemplateLimits(t *testing.T) {
+	data := map[string]any{
+		"items": []any{1, 2, 3, 4},
+	}
+
+	t.Run("max output bytes", func(t *testing.T) {
+		transformer, err := NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{
+			ContentType: "text/plain",
+			Template:    `{{ range .items }}{{ . }}-{{ end }}`,
+			Limits:      &GoTemplateLimits{MaxOutputBytes: 5},
+		})
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		_, err = transformer.Transform(data)
+		if !errors.Is(err, ErrLimitExceeded) {
+			t.Fatalf("expected ErrLimitExceeded, got: %v", err)
+		}
+	})
+
+	t.Run("max iterations of nested ranges", func(t *testing.T) {
+		for _, contentType := range []string{"text/plain", "text/html"} {
+			config := &GoTemplateTransformerConfig{
+				ContentType: contentType,
+				Template:    `{{ range $a := .items }}{{ range $b := $.items }}{{ end }}{{ end }}`,
+				Limits:      &GoTemplateLimits{MaxIterations: 10},
+			}
+
+			transformer, err := NewGoTemplateTransformer("test", config)
+			if err != nil {
+				t.Fatalf("failed to create transformer: %v", err)
+			}
+
+			_, err = transformer.Transform(data)
+			if !errors.Is(err, ErrLimitExceeded) {
+				t.Fatalf("%s: expected ErrLimitExceeded, got: %v", contentType, err)
+			}
+
+			config.Limits.MaxIterations = 20
+
+			transformer, err = NewGoTemplateTransformer("test", config)
+			if err != nil {
+				t.Fatalf("failed to create transformer: %v", err)
+			}
+
+			_, err = transformer.Transform(data)
+			if err != nil {
+				t.Fatalf("%s: expected no error, got: %v", contentType, err)
+			}
+		}
+	})
+
+	t.Run("location of the exceeded range", func(t *testing.T) {
+		for _, contentType := range []string{"text/plain", "text/html"} {
+			transformer, err := NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{
+				ContentType: contentType,
+				Template:    "items:\n{{ range .items }}{{ . }}{{ end }}",
+				Limits:      &GoTemplateLimits{MaxIterations: 2},
+			})
+			if err != nil {
+				t.Fatalf("failed to create transformer: %v", err)
+			}
+
+			_, err = transformer.Transform(data)
+			if !errors.Is(err, ErrLimitExceeded) {
+				t.Fatalf("%s: expected ErrLimitExceeded, got: %v", contentType, err)
+			}
+
+			expected := "template: test:2:9: template resource limit exceeded: iterations exceed 2"
+			if !strings.HasSuffix(err.Error(), expected) || strings.Contains(err.Error(), iterationFuncName) {
+				t.Errorf("%s: expected error %q, got: %v", contentType, expected, err)
+			}
+		}
+	})
+
+	t.Run("functions which build values in memory", func(t *testing.T) {
+		testCases := []struct {
+			Template string
+			Valid    bool
+		}{
+			{Template: `{{ repeat 5 "ab" | len }}`, Valid: true},
+			{Template: `{{ repeat 6 "ab" | len }}`},
+			{Template: `{{ repeat 1000000000000 "ab" | len }}`},
+			{Template: `{{ until 10 | len }}`, Valid: true},
+			{Template: `{{ until -11 | len }}`},
+			{Template: `{{ untilStep 0 100 10 | len }}`, Valid: true},
+			{Template: `{{ untilStep 0 101 10 | len }}`},
+			{Template: `{{ untilStep 0 1000000000000 -1 | len }}`, Valid: true},
+		}
+
+		for _, tc := range testCases {
+			transformer, err := NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{
+				ContentType: "text/plain",
+				Template:    tc.Template,
+				Limits:      &GoTemplateLimits{MaxOutputBytes: 10, MaxIterations: 10},
+			})
+			if err != nil {
+				t.Fatalf("failed to create transformer: %v", err)
+			}
+
+			_, err = transformer.Transform(nil)
+			if tc.Valid && err != nil {
+				t.Errorf("%s: expected no error, got: %v", tc.Template, err)
+			}
+
+			if !tc.Valid && !errors.Is(err, ErrLimitExceeded) {
+				t.Errorf("%s: expected ErrLimitExceeded, got: %v", tc.Template, err)
+			}
+		}
+	})
+
+	t.Run("iterations of named templates are counted per execution", func(t *testing.T) {
+		transformer, err := NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{
+			ContentType: "text/plain",
+			Template:    `{{ template "list" .items }}`,
+			Templates: map[string]string{
+				"list": `{{ range . }}{{ . }}{{ end }}`,
+			},
+			Limits: &GoTemplateLimits{MaxIterations: 4},
+		})
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		for range 3 {
+			result, err := transformer.Transform(data)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if result != "1234" {
+				t.Errorf("expected result to be %q, got: %q", "1234", result)
+			}
+		}
+
+		_, err = transformer.Transform(map[string]any{"items": []any{1, 2, 3, 4, 5}})
+		if !errors.Is(err, ErrLimitExceeded) {
+			t.Fatalf("expected ErrLimitExceeded, got: %v", err)
+		}
+	})
+
+	t.Run("output of html contexts is not altered", func(t *testing.T) {
+		transformer, err := NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{
+			ContentType: "text/html",
+			Template: `<ul>{{ range .items }}<li>{{ . }}</li>{{ end }}</ul>` +
+				`<script>var items = [{{ range .items }}{{ . }},{{ end }}];</script>`,
+			Limits: &GoTemplateLimits{MaxIterations: 100, MaxExecutionTime: "10s"},
+		})
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		result, err := transformer.Transform(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := `<ul><li>1</li><li>2</li><li>3</li><li>4</li></ul>` +
+			`<script>var items = [ 1 , 2 , 3 , 4 ,];</script>`
+		if result != expected {
+			t.Errorf("expected result to be %q, got: %q", expected, result)
+		}
+	})
+
+	t.Run("max execution time", func(t *testing.T) {
+		transformer, err := NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{
+			ContentType: "text/plain",
+			Template:    `{{ $items := until 10000 }}{{ range $items }}{{ range $items }}{{ end }}{{ end }}`,
+			Limits:      &GoTemplateLimits{MaxExecutionTime: "10ms"},
+		})
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		_, err = transformer.Transform(nil)
+		if !errors.Is(err, ErrLimitExceeded) {
+			t.Fatalf("expected ErrLimitExceeded, got: %v", err)
+		}
+	})
+
+	t.Run("invalid limits", func(t *testing.T) {
+		for name, limits := range map[string]*GoTemplateLimits{
+			"negative output bytes": {MaxOutputBytes: -1},
+			"negative iterations":   {MaxIterations: -1},
+			"invalid duration":      {MaxExecutionTime: "soon"},
+			"negative duration":     {MaxExecutionTime: "-1s"},
+		} {
+			config := GoTemplateTransformerConfig{
+				Template: "hello",
+				Limits:   limits,
+			}
+
+			err := config.Validate()
+			if !errors.Is(err, ErrInvalidLimits) {
+				t.Errorf("%s: expected ErrInvalidLimits, got: %v", name, err)
+			}
+
+			_, err = NewGoTemplateTransformer("test", &config)
+			if !errors.Is(err, ErrInvalidLimits) {
+				t.Errorf("%s: expected ErrInvalidLimits, got: %v", name, err)
+			}
+		}
+	})
+
+	t.Run("unlimited output is not truncated", func(t *testing.T) {
+		transformer, err := NewGoTemplateTransformer("test", &GoTemplateTransformerConfig{
+			ContentType: "text/plain",
+			Template:    `{{ repeat 1000 "a" }}`,
+			Limits:      &GoTemplateLimits{MaxOutputBytes: 1000},
+		})
+		if err != nil {
+			t.Fatalf("failed to create transformer: %v", err)
+		}
+
+		result, err := transformer.Transform(nil)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if result != strings.Repeat("a", 1000) {
+			t.Errorf("unexpected output size: %d", len(result.(string)))
+		}
+	})
+}
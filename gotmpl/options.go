@@ -0,0 +1,93 @@
+package gotmpl
+
+import (
+	"io/fs"
+	"maps"
+	"math/rand/v2"
+	"os"
+	"text/template"
+	"time"
+)
+
+// GoTemplateTransformerOption is a function to configure the construction of a Go template transformer.
+type GoTemplateTransformerOption func(*goTemplateTransformerOptions)
+
+type goTemplateTransformerOptions struct {
+	fsys       fs.FS
+	funcs      []template.FuncMap
+	clock      func() time.Time
+	randSource rand.Source
+}
+
+func (opts goTemplateTransformerOptions) files() templateFiles {
+	return templateFiles{fsys: opts.fsys}
+}
+
+// overrideFuncs returns functions which replace sprig functions.
+func (opts goTemplateTransformerOptions) overrideFuncs() template.FuncMap {
+	result := template.FuncMap{}
+
+	if opts.clock != nil {
+		maps.Copy(result, clockFuncs(opts.clock))
+	}
+
+	if opts.randSource != nil {
+		maps.Copy(result, randomFuncs(opts.randSource))
+	}
+
+	return result
+}
+
+// funcMap builds functions of the template and applies the function policy.
+func (opts goTemplateTransformerOptions) funcMap(functions *GoTemplateFunctions) (template.FuncMap, error) {
+	result, err := buildFuncMap(opts.overrideFuncs(), opts.funcs)
+	if err != nil {
+		return nil, err
+	}
+
+	if functions != nil {
+		err := functions.apply(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// WithFileSystem sets the file system to resolve template files and partials.
+// Paths are resolved by the operating system relative to the working directory by default.
+func WithFileSystem(fsys fs.FS) GoTemplateTransformerOption {
+	return func(opts *goTemplateTransformerOptions) {
+		opts.fsys = fsys
+	}
+}
+
+// WithBaseDir resolves template files and partials relative to the directory, e.g. the directory of the config file.
+func WithBaseDir(dir string) GoTemplateTransformerOption {
+	return WithFileSystem(os.DirFS(dir))
+}
+
+// WithFuncs adds custom functions to the template in addition to sprig and registered functions.
+// Names must not conflict with other functions.
+func WithFuncs(funcs template.FuncMap) GoTemplateTransformerOption {
+	return func(opts *goTemplateTransformerOptions) {
+		opts.funcs = append(opts.funcs, funcs)
+	}
+}
+
+// WithClock overrides sprig functions which depend on the current time, i.e. now, ago, date, dateInZone,
+// htmlDate, htmlDateInZone and durationRound, e.g. to render deterministic outputs in tests.
+func WithClock(clock func() time.Time) GoTemplateTransformerOption {
+	return func(opts *goTemplateTransformerOptions) {
+		opts.clock = clock
+	}
+}
+
+// WithRandSource overrides sprig random functions, e.g. randAlphaNum, randInt, shuffle and uuidv4,
+// to generate values from the source. A seeded source renders reproducible outputs.
+func WithRandSource(source rand.Source) GoTemplateTransformerOption {
+	return func(opts *goTemplateTransformerOptions) {
+		opts.randSource = source
+	}
+}
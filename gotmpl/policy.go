@@ -0,0 +1,146 @@
+package gotmpl
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"text/template"
+)
+
+var (
+	// ErrUnsupportedFunctionPolicy occurs when the function policy is not supported.
+	ErrUnsupportedFunctionPolicy = errors.New("unsupported function policy")
+	// ErrUnknownTemplateFunction occurs when the allow or deny list of the function policy contains
+	// a name which is neither a sprig, registered nor custom function.
+	ErrUnknownTemplateFunction = errors.New("unknown template function")
+)
+
+// FunctionPolicy represents the base set enum of functions which are available to templates.
+type FunctionPolicy string
+
+const (
+	// FunctionPolicyFull enables all sprig and custom functions.
+	FunctionPolicyFull FunctionPolicy = "full"
+	// FunctionPolicySafe disables sprig functions which read environment variables, perform IO or generate random values.
+	FunctionPolicySafe FunctionPolicy = "safe"
+)
+
+var enumValuesFunctionPolicy = []FunctionPolicy{FunctionPolicyFull, FunctionPolicySafe}
+
+// Validate checks if the function policy is valid.
+func (fp FunctionPolicy) Validate() error {
+	if !slices.Contains(enumValuesFunctionPolicy, fp) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedFunctionPolicy, fp)
+	}
+
+	return nil
+}
+
+// unsafeFuncNames are sprig functions which are disabled by the safe policy.
+var unsafeFuncNames = []string{
+	// environment variables and IO
+	"env",
+	"expandenv",
+	"getHostByName",
+	// random values
+	"randAlpha",
+	"randAlphaNum",
+	"randAscii",
+	"randBytes",
+	"randInt",
+	"randNumeric",
+	"shuffle",
+	"uuidv4",
+	// random salts, initialization vectors and keys
+	"bcrypt",
+	"htpasswd",
+	"encryptAES",
+	"genPrivateKey",
+	"genCA",
+	"genCAWithKey",
+	"genSelfSignedCert",
+	"genSelfSignedCertWithKey",
+	"genSignedCert",
+	"genSignedCertWithKey",
+}
+
+// GoTemplateFunctions represents the policy of functions which are available to the template.
+// Templates which call disabled functions fail to be parsed.
+type GoTemplateFunctions struct {
+	// Policy is the base set of available functions. Defaults to full.
+	Policy FunctionPolicy `json:"policy,omitempty" yaml:"policy,omitempty"`
+	// Allow restricts available functions of the base set to the list if not empty.
+	// Builtin functions of Go templates, e.g. len and printf, are always available and can not be listed.
+	Allow []string `json:"allow,omitempty" yaml:"allow,omitempty"`
+	// Deny disables functions in the list. Names must be sprig, registered or custom functions.
+	Deny []string `json:"deny,omitempty" yaml:"deny,omitempty"`
+}
+
+// IsZero checks if the function policy is empty.
+func (gf GoTemplateFunctions) IsZero() bool {
+	return gf.Policy == "" && len(gf.Allow) == 0 && len(gf.Deny) == 0
+}
+
+// Equal checks if this instance equals the target value.
+func (gf GoTemplateFunctions) Equal(target GoTemplateFunctions) bool {
+	return gf.Policy == target.Policy &&
+		slices.Equal(gf.Allow, target.Allow) &&
+		slices.Equal(gf.Deny, target.Deny)
+}
+
+// Validate checks if the function policy is valid.
+func (gf GoTemplateFunctions) Validate() error {
+	if gf.Policy != "" {
+		return gf.Policy.Validate()
+	}
+
+	return nil
+}
+
+// apply removes disabled functions from the function map.
+// It returns an error if the allow or deny list contains a name which is not in the function map, e.g. a typo.
+func (gf GoTemplateFunctions) apply(funcMap template.FuncMap) error {
+	for _, name := range slices.Concat(gf.Allow, gf.Deny) {
+		if _, ok := funcMap[name]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownTemplateFunction, name)
+		}
+	}
+
+	if gf.Policy == FunctionPolicySafe {
+		for _, name := range unsafeFuncNames {
+			delete(funcMap, name)
+		}
+	}
+
+	if len(gf.Allow) > 0 {
+		for name := range funcMap {
+			if !slices.Contains(gf.Allow, name) {
+				delete(funcMap, name)
+			}
+		}
+	}
+
+	for _, name := range gf.Deny {
+		delete(funcMap, name)
+	}
+
+	return nil
+}
+
+func (gf GoTemplateFunctions) toMap() map[string]any {
+	result := map[string]any{}
+
+	if gf.Policy != "" {
+		result["policy"] = gf.Policy
+	}
+
+	if len(gf.Allow) > 0 {
+		result["allow"] = gf.Allow
+	}
+
+	if len(gf.Deny) > 0 {
+		result["deny"] = gf.Deny
+	}
+
+	return result
+}
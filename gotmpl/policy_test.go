@@ -0,0 +1,126 @@
+package gotmpl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestGoTemplateFunctions(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Functions *GoTemplateFunctions
+		Template  string
+		Valid     bool
+	}{
+		{
+			Name:      "full policy",
+			Functions: &GoTemplateFunctions{Policy: FunctionPolicyFull},
+			Template:  `{{ env "HOME" }}`,
+			Valid:     true,
+		},
+		{
+			Name:      "safe policy disables env",
+			Functions: &GoTemplateFunctions{Policy: FunctionPolicySafe},
+			Template:  `{{ env "HOME" }}`,
+		},
+		{
+			Name:      "safe policy disables random functions",
+			Functions: &GoTemplateFunctions{Policy: FunctionPolicySafe},
+			Template:  `{{ randAlphaNum 10 }}`,
+		},
+		{
+			Name:      "safe policy keeps other functions",
+			Functions: &GoTemplateFunctions{Policy: FunctionPolicySafe},
+			Template:  `{{ .name | upper | quote }}`,
+			Valid:     true,
+		},
+		{
+			Name:      "allow list",
+			Functions: &GoTemplateFunctions{Allow: []string{"upper"}},
+			Template:  `{{ .name | upper }}`,
+			Valid:     true,
+		},
+		{
+			Name:      "function outside of the allow list",
+			Functions: &GoTemplateFunctions{Allow: []string{"upper"}},
+			Template:  `{{ .name | lower }}`,
+		},
+		{
+			Name:      "allow list does not extend the safe policy",
+			Functions: &GoTemplateFunctions{Policy: FunctionPolicySafe, Allow: []string{"env"}},
+			Template:  `{{ env "HOME" }}`,
+		},
+		{
+			Name:      "deny list",
+			Functions: &GoTemplateFunctions{Deny: []string{"upper"}},
+			Template:  `{{ .name | upper }}`,
+		},
+		{
+			Name:      "deny custom functions",
+			Functions: &GoTemplateFunctions{Deny: []string{"mask"}},
+			Template:  `{{ .name | mask }}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			config := &GoTemplateTransformerConfig{
+				ContentType: "text/plain",
+				Template:    tc.Template,
+				Functions:   tc.Functions,
+			}
+
+			_, err := NewGoTemplateTransformer("test", config, WithFuncs(template.FuncMap{
+				"mask": strings.ToUpper,
+			}))
+			if tc.Valid && err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if !tc.Valid && err == nil {
+				t.Fatal("expected error for disabled function, got nil")
+			}
+		})
+	}
+
+	t.Run("unsafe functions exist in sprig", func(t *testing.T) {
+		for _, name := range unsafeFuncNames {
+			if _, ok := sprigFuncNames[name]; !ok {
+				t.Errorf("%s is not a sprig function", name)
+			}
+		}
+	})
+
+	t.Run("unknown functions", func(t *testing.T) {
+		for _, functions := range []GoTemplateFunctions{
+			{Deny: []string{"Env"}},
+			{Allow: []string{"upper", "uper"}},
+			{Deny: []string{"printf"}},
+		} {
+			config := &GoTemplateTransformerConfig{
+				ContentType: "text/plain",
+				Template:    "hello",
+				Functions:   &functions,
+			}
+
+			_, err := NewGoTemplateTransformer("test", config)
+			if !errors.Is(err, ErrUnknownTemplateFunction) {
+				t.Errorf("%v: expected ErrUnknownTemplateFunction, got: %v", functions, err)
+			}
+		}
+	})
+
+	t.Run("unsupported policy", func(t *testing.T) {
+		config := GoTemplateTransformerConfig{
+			Template:  "hello",
+			Functions: &GoTemplateFunctions{Policy: "none"},
+		}
+
+		err := config.Validate()
+		if !errors.Is(err, ErrUnsupportedFunctionPolicy) {
+			t.Fatalf("expected ErrUnsupportedFunctionPolicy, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,280 @@
+package jmes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CastType represents the target type enum of a field cast.
+type CastType string
+
+const (
+	CastTypeString   CastType = "string"
+	CastTypeInteger  CastType = "integer"
+	CastTypeNumber   CastType = "number"
+	CastTypeBoolean  CastType = "boolean"
+	CastTypeDateTime CastType = "datetime"
+)
+
+var enumValuesCastType = []CastType{
+	CastTypeString,
+	CastTypeInteger,
+	CastTypeNumber,
+	CastTypeBoolean,
+	CastTypeDateTime,
+}
+
+// CastMode represents the behavior enum when a value can not be converted.
+type CastMode string
+
+const (
+	// CastModeStrict returns an error if the value can not be converted.
+	CastModeStrict CastMode = "strict"
+	// CastModeLenient treats values that can not be converted as null, so the default value is used.
+	CastModeLenient CastMode = "lenient"
+)
+
+var (
+	errUnsupportedCastType = errors.New("unsupported cast type")
+	errUnsupportedCastMode = errors.New("unsupported cast mode")
+	errCastLayoutDateTime  = errors.New("layout is only supported by the datetime cast")
+	errCastExpectedScalar  = errors.New("expected a scalar value")
+	errCastExpectedNumber  = errors.New("expected a number or string")
+	errCastExpectedBoolean = errors.New("expected a boolean, 0, 1 or a boolean string")
+	errCastExpectedTime    = errors.New("expected a date-time string")
+	errCastNotInteger      = errors.New("value is not an integer")
+	errCastIntOverflow     = errors.New("value overflows int64")
+)
+
+var (
+	truthyStrings = []string{"true", "t", "1", "y", "yes", "on"}
+	falsyStrings  = []string{"false", "f", "0", "n", "no", "off"}
+)
+
+// FieldCast represents the type coercion of a field value after it is looked up from the JMES path.
+type FieldCast struct {
+	// Type is the target type of the cast.
+	Type CastType `json:"type" yaml:"type" jsonschema:"enum=string,enum=integer,enum=number,enum=boolean,enum=datetime,description=Target type of the cast"`
+	// Layout of the input date-time value in Go time format. Defaults to RFC3339.
+	Layout string `json:"layout,omitempty" yaml:"layout,omitempty" jsonschema:"description=Layout of the input date-time value in Go time format. Defaults to RFC3339"`
+	// Mode is the behavior when the value can not be converted. Defaults to strict.
+	Mode CastMode `json:"mode,omitempty" yaml:"mode,omitempty" jsonschema:"enum=strict,enum=lenient,default=strict,description=Behavior when the value can not be converted"`
+}
+
+// Validate checks if the cast config is valid.
+func (fc FieldCast) Validate() error {
+	if !slices.Contains(enumValuesCastType, fc.Type) {
+		return fmt.Errorf("%w: %w: %s", ErrFieldMappingEntryMalformed, errUnsupportedCastType, fc.Type)
+	}
+
+	if fc.Mode != "" && fc.Mode != CastModeStrict && fc.Mode != CastModeLenient {
+		return fmt.Errorf("%w: %w: %s", ErrFieldMappingEntryMalformed, errUnsupportedCastMode, fc.Mode)
+	}
+
+	if fc.Layout != "" && fc.Type != CastTypeDateTime {
+		return fmt.Errorf("%w: %w", ErrFieldMappingEntryMalformed, errCastLayoutDateTime)
+	}
+
+	return nil
+}
+
+// Cast converts the value to the target type.
+// In lenient mode, values that can not be converted become null.
+func (fc FieldCast) Cast(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	result, err := fc.cast(value)
+	if err != nil {
+		if fc.Mode == CastModeLenient {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf(
+			"%w, failed to cast %v (%T) to %s: %w",
+			ErrFieldMappingEntryMalformed,
+			value,
+			value,
+			fc.Type,
+			err,
+		)
+	}
+
+	return result, nil
+}
+
+func (fc FieldCast) cast(value any) (any, error) {
+	switch fc.Type {
+	case CastTypeString:
+		return castString(value)
+	case CastTypeInteger:
+		return castInteger(value)
+	case CastTypeNumber:
+		return castNumber(value)
+	case CastTypeBoolean:
+		return castBoolean(value)
+	case CastTypeDateTime:
+		return castDateTime(value, fc.Layout)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedCastType, fc.Type)
+	}
+}
+
+func castString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64), nil
+	default:
+		return "", errCastExpectedScalar
+	}
+}
+
+// castInteger converts the value to an integer. Numeric strings are accepted if they are integral, e.g. 1.0 or 1e3.
+func castInteger(value any) (int64, error) {
+	switch v := value.(type) {
+	case string:
+		str := strings.TrimSpace(v)
+
+		result, err := strconv.ParseInt(str, 10, 64)
+		if err == nil {
+			return result, nil
+		}
+
+		f, floatErr := strconv.ParseFloat(str, 64)
+		if floatErr != nil {
+			return 0, err
+		}
+
+		return floatToInteger(f)
+	case bool:
+		if v {
+			return 1, nil
+		}
+
+		return 0, nil
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, errCastIntOverflow
+		}
+
+		return int64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return floatToInteger(rv.Float())
+	default:
+		return 0, errCastExpectedNumber
+	}
+}
+
+// floatToInteger converts the integral float to an integer.
+// float64(math.MaxInt64) rounds up to 2^63, so the upper bound is exclusive.
+func floatToInteger(f float64) (int64, error) {
+	if f >= math.MaxInt64 || f < math.MinInt64 {
+		return 0, errCastIntOverflow
+	}
+
+	if f != math.Trunc(f) {
+		return 0, errCastNotInteger
+	}
+
+	return int64(f), nil
+}
+
+func castNumber(value any) (float64, error) {
+	if str, ok := value.(string); ok {
+		return strconv.ParseFloat(strings.TrimSpace(str), 64)
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	default:
+		return 0, errCastExpectedNumber
+	}
+}
+
+func castBoolean(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		str := strings.ToLower(strings.TrimSpace(v))
+
+		switch {
+		case slices.Contains(truthyStrings, str):
+			return true, nil
+		case slices.Contains(falsyStrings, str):
+			return false, nil
+		default:
+			return false, fmt.Errorf("%w, got %q", errCastExpectedBoolean, v)
+		}
+	}
+
+	number, err := castNumber(value)
+	if err != nil {
+		return false, errCastExpectedBoolean
+	}
+
+	switch number {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, errCastExpectedBoolean
+	}
+}
+
+func castDateTime(value any, layout string) (string, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case string:
+		if layout == "" {
+			layout = time.RFC3339
+		}
+
+		t, err := time.Parse(layout, strings.TrimSpace(v))
+		if err != nil {
+			return "", err
+		}
+
+		return t.Format(time.RFC3339Nano), nil
+	default:
+		return "", errCastExpectedTime
+	}
+}
@@ -0,0 +1,143 @@
+package jmes
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFieldCast_Validate(t *testing.T) {
+	t.Run("valid cast", func(t *testing.T) {
+		cast := FieldCast{Type: CastTypeDateTime, Layout: "2006-01-02", Mode: CastModeLenient}
+		if err := cast.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	t.Run("error with unsupported type", func(t *testing.T) {
+		cast := FieldCast{Type: "unknown"}
+		if err := cast.Validate(); !errors.Is(err, ErrFieldMappingEntryMalformed) {
+			t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("error with unsupported mode", func(t *testing.T) {
+		cast := FieldCast{Type: CastTypeString, Mode: "unknown"}
+		if err := cast.Validate(); !errors.Is(err, ErrFieldMappingEntryMalformed) {
+			t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("error with layout of non datetime cast", func(t *testing.T) {
+		cast := FieldCast{Type: CastTypeString, Layout: "2006-01-02"}
+		if err := cast.Validate(); !errors.Is(err, ErrFieldMappingEntryMalformed) {
+			t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+		}
+	})
+}
+
+func TestFieldCast_Cast(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Cast     FieldCast
+		Input    any
+		Expected any
+	}{
+		{Name: "string from number", Cast: FieldCast{Type: CastTypeString}, Input: 1.5, Expected: "1.5"},
+		{Name: "string from int", Cast: FieldCast{Type: CastTypeString}, Input: 10, Expected: "10"},
+		{Name: "string from bool", Cast: FieldCast{Type: CastTypeString}, Input: true, Expected: "true"},
+		{Name: "integer from string", Cast: FieldCast{Type: CastTypeInteger}, Input: " 42 ", Expected: int64(42)},
+		{Name: "integer from float", Cast: FieldCast{Type: CastTypeInteger}, Input: float64(3), Expected: int64(3)},
+		{Name: "integer from integral decimal string", Cast: FieldCast{Type: CastTypeInteger}, Input: "1.0", Expected: int64(1)},
+		{Name: "integer from exponent string", Cast: FieldCast{Type: CastTypeInteger}, Input: "1e3", Expected: int64(1000)},
+		{Name: "number from string", Cast: FieldCast{Type: CastTypeNumber}, Input: "2.5", Expected: 2.5},
+		{Name: "number from int", Cast: FieldCast{Type: CastTypeNumber}, Input: 2, Expected: float64(2)},
+		{Name: "boolean from Y", Cast: FieldCast{Type: CastTypeBoolean}, Input: "Y", Expected: true},
+		{Name: "boolean from N", Cast: FieldCast{Type: CastTypeBoolean}, Input: "N", Expected: false},
+		{Name: "boolean from number", Cast: FieldCast{Type: CastTypeBoolean}, Input: float64(1), Expected: true},
+		{Name: "datetime from RFC3339", Cast: FieldCast{Type: CastTypeDateTime}, Input: "2024-01-02T03:04:05+07:00", Expected: "2024-01-02T03:04:05+07:00"},
+		{
+			Name:     "datetime with layout",
+			Cast:     FieldCast{Type: CastTypeDateTime, Layout: "02/01/2006 15:04"},
+			Input:    "25/12/2024 10:30",
+			Expected: "2024-12-25T10:30:00Z",
+		},
+		{Name: "lenient integer", Cast: FieldCast{Type: CastTypeInteger, Mode: CastModeLenient}, Input: "abc", Expected: nil},
+		{Name: "null input", Cast: FieldCast{Type: CastTypeInteger}, Input: nil, Expected: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := tc.Cast.Cast(tc.Input)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if result != tc.Expected {
+				t.Errorf("expected %v (%T), got: %v (%T)", tc.Expected, tc.Expected, result, result)
+			}
+		})
+	}
+
+	errorCases := []struct {
+		Name  string
+		Cast  FieldCast
+		Input any
+	}{
+		{Name: "integer from decimal string", Cast: FieldCast{Type: CastTypeInteger}, Input: "1.5"},
+		{Name: "integer from decimal number", Cast: FieldCast{Type: CastTypeInteger}, Input: 1.5},
+		{Name: "number from object", Cast: FieldCast{Type: CastTypeNumber}, Input: map[string]any{}},
+		{Name: "string from array", Cast: FieldCast{Type: CastTypeString}, Input: []any{"a"}},
+		{Name: "boolean from unknown string", Cast: FieldCast{Type: CastTypeBoolean}, Input: "maybe"},
+		{Name: "boolean from number", Cast: FieldCast{Type: CastTypeBoolean}, Input: 2},
+		{Name: "datetime with invalid layout", Cast: FieldCast{Type: CastTypeDateTime}, Input: "2024-01-02"},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := tc.Cast.Cast(tc.Input)
+			if !errors.Is(err, ErrFieldMappingEntryMalformed) {
+				t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestCastInteger_Boundaries(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    any
+		Expected int64
+		Error    error
+	}{
+		{Name: "min int64", Input: -math.Pow(2, 63), Expected: math.MinInt64},
+		{Name: "2^63", Input: math.Pow(2, 63), Error: errCastIntOverflow},
+		{Name: "-2^63 - 2^11", Input: -math.Pow(2, 63) - math.Pow(2, 11), Error: errCastIntOverflow},
+		{Name: "2^63 string", Input: "9223372036854775808", Error: errCastIntOverflow},
+		{Name: "max int64 string", Input: "9223372036854775807", Expected: math.MaxInt64},
+		{Name: "infinity", Input: math.Inf(1), Error: errCastIntOverflow},
+		{Name: "not a number", Input: math.NaN(), Error: errCastNotInteger},
+		{Name: "decimal string", Input: "1.5", Error: errCastNotInteger},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := castInteger(tc.Input)
+			if tc.Error != nil {
+				if !errors.Is(err, tc.Error) {
+					t.Fatalf("expected error %v, got: %v, %v", tc.Error, result, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if result != tc.Expected {
+				t.Errorf("expected %d, got: %d", tc.Expected, result)
+			}
+		})
+	}
+}
@@ -2,13 +2,23 @@ package jmes
 
 import (
 	"encoding/json"
+	"fmt"
 
+	"github.com/hasura/goenvconf"
+	"github.com/relychan/gotransform/gotmpl"
 	"github.com/relychan/gotransform/transformtypes"
+	"github.com/relychan/goutils"
 )
 
 // JMESTransformerConfig represents configurations for the Go template transformer.
 type JMESTransformerConfig struct {
 	Template FieldMappingConfig `json:"template" yaml:"template"`
+	// Definitions are named field mappings which can be reused in the template with ref mappings.
+	Definitions map[string]FieldMappingConfig `json:"definitions,omitempty" yaml:"definitions,omitempty"`
+	// Variables are constant values or environment variables which are addressable with $vars in JMESPath expressions.
+	Variables map[string]goenvconf.EnvAny `json:"variables,omitempty" yaml:"variables,omitempty"`
+	// LookupTables are named tables which translate values of field mappings with lookup options.
+	LookupTables map[string]map[string]any `json:"lookupTables,omitempty" yaml:"lookupTables,omitempty"`
 }
 
 var _ transformtypes.TemplateTransformerConfig = (*JMESTransformerConfig)(nil)
@@ -25,7 +35,10 @@ func (jt JMESTransformerConfig) IsZero() bool {
 
 // Equal checks if this instance equals the target value.
 func (jt JMESTransformerConfig) Equal(target JMESTransformerConfig) bool {
-	return jt.Template.Equal(target.Template)
+	return jt.Template.Equal(target.Template) &&
+		goutils.EqualMap(jt.Definitions, target.Definitions, true) &&
+		goutils.EqualMap(jt.Variables, target.Variables, true) &&
+		goutils.DeepEqual(jt.LookupTables, target.LookupTables, true)
 }
 
 // Validate checks if the config is valid.
@@ -37,20 +50,79 @@ func (jt JMESTransformerConfig) Validate() error {
 	return nil
 }
 
-// MarshalJSON implements the json.Marshaler interface.
-func (jt JMESTransformerConfig) MarshalJSON() ([]byte, error) {
-	result := map[string]any{
-		"type":     jt.Type(),
-		"template": jt.Template,
+// Evaluate converts the template and definitions to the field mapping instance.
+// References to definitions are resolved and checked for cycles, then named lookup tables are bound.
+// Go templates of field mappings are parsed with functions of the options.
+func (jt JMESTransformerConfig) Evaluate(
+	getEnvFunc goenvconf.GetEnvFunc,
+	options ...gotmpl.GoTemplateTransformerOption,
+) (FieldMapping, error) {
+	template, err := jt.Template.Evaluate(getEnvFunc)
+	if err != nil {
+		return FieldMapping{}, err
+	}
+
+	err = EvaluateDefinitions(template, jt.Definitions, getEnvFunc)
+	if err != nil {
+		return FieldMapping{}, err
+	}
+
+	err = bindLookupTables(template, jt.LookupTables)
+	if err != nil {
+		return FieldMapping{}, err
+	}
+
+	err = BindTemplateOptions(template, options...)
+	if err != nil {
+		return FieldMapping{}, err
+	}
+
+	return template, nil
+}
+
+// EvaluateVariables resolves values of variables.
+func (jt JMESTransformerConfig) EvaluateVariables(getEnvFunc goenvconf.GetEnvFunc) (map[string]any, error) {
+	result := make(map[string]any, len(jt.Variables))
+
+	for name, variable := range jt.Variables {
+		value, err := variable.GetCustom(getEnvFunc)
+		if err != nil {
+			return nil, fmt.Errorf("variables.%s: %w", name, err)
+		}
+
+		result[name] = value
 	}
 
-	return json.Marshal(result)
+	return result, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (jt JMESTransformerConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jt.toMap())
 }
 
 // MarshalYAML implements the yaml.Marshaler interface.
 func (jt JMESTransformerConfig) MarshalYAML() (any, error) {
-	return map[string]any{
+	return jt.toMap(), nil
+}
+
+func (jt JMESTransformerConfig) toMap() map[string]any {
+	result := map[string]any{
 		"type":     jt.Type(),
 		"template": jt.Template,
-	}, nil
+	}
+
+	if len(jt.Definitions) > 0 {
+		result["definitions"] = jt.Definitions
+	}
+
+	if len(jt.Variables) > 0 {
+		result["variables"] = jt.Variables
+	}
+
+	if len(jt.LookupTables) > 0 {
+		result["lookupTables"] = jt.LookupTables
+	}
+
+	return result
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/hasura/goenvconf"
 	"github.com/relychan/gotransform/transformtypes"
 )
 
@@ -120,3 +121,33 @@ func TestJMESTransformerConfig_Equal(t *testing.T) {
 		}
 	})
 }
+
+func TestJMESTransformerConfig_EvaluateVariables(t *testing.T) {
+	config := JMESTransformerConfig{
+		Variables: map[string]goenvconf.EnvAny{
+			"currency": goenvconf.NewEnvAnyValue("USD"),
+			"limit":    goenvconf.NewEnvAnyVariable("TEST_LIMIT"),
+		},
+	}
+
+	getEnvFunc := func(name string) (string, error) {
+		if name == "TEST_LIMIT" {
+			return "10", nil
+		}
+
+		return "", nil
+	}
+
+	result, err := config.EvaluateVariables(getEnvFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if result["currency"] != "USD" {
+		t.Errorf("expected currency to be USD, got: %v", result["currency"])
+	}
+
+	if result["limit"] != float64(10) {
+		t.Errorf("expected limit to be 10, got: %v", result["limit"])
+	}
+}
@@ -0,0 +1,484 @@
+package jmes
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/hasura/goenvconf"
+	"github.com/relychan/goutils"
+)
+
+var (
+	// ErrFieldMappingDictionaryMalformed occurs when the config of a dictionary or entries mapping is invalid.
+	ErrFieldMappingDictionaryMalformed = errors.New("field mapping dictionary is malformed")
+	// ErrFieldMappingDictionaryKey occurs when the key of a dictionary item is not a scalar value or is duplicated.
+	ErrFieldMappingDictionaryKey = errors.New("invalid dictionary key")
+)
+
+// Default property names of entries which are produced by the entries mapping.
+const (
+	defaultEntryKeyName   = "key"
+	defaultEntryValueName = "value"
+)
+
+// FieldMappingDictionary converts an array to an object whose keys are derived from each item.
+type FieldMappingDictionary struct {
+	// Path is a JMESPath expression to select the array. The current input data is used if nil.
+	Path *string
+	// Key is a JMESPath expression to select the key of each item.
+	Key string
+	// Value is the mapping of each item. The item is used as is if nil.
+	Value *FieldMapping
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy
+}
+
+var _ FieldMappingInterface = (*FieldMappingDictionary)(nil)
+
+// Type returns type of the field mapping dictionary.
+func (FieldMappingDictionary) Type() FieldMappingType {
+	return FieldMappingTypeDictionary
+}
+
+// IsZero checks if the field mapping dictionary is empty.
+func (fm FieldMappingDictionary) IsZero() bool {
+	return fm.Key == ""
+}
+
+// Equal checks if this instance equals the target value.
+func (fm FieldMappingDictionary) Equal(target FieldMappingDictionary) bool {
+	return fm.Key == target.Key &&
+		fm.OnError == target.OnError &&
+		goutils.EqualComparablePtr(fm.Path, target.Path) &&
+		goutils.EqualPtr(fm.Value, target.Value)
+}
+
+// Evaluate converts the selected array to an object.
+// It returns an error if keys are not scalar values or duplicated.
+func (fm FieldMappingDictionary) Evaluate(data any) (any, error) {
+	result, err := fm.evaluateScope(newEvaluationScope(data, nil))
+	if errors.Is(err, errFieldOmitted) {
+		return nil, nil
+	}
+
+	return result, err
+}
+
+func (fm FieldMappingDictionary) evaluateScope(scope *evaluationScope) (any, error) {
+	input, err := selectPathInput(scope, fm.Path)
+	if err != nil || input == nil {
+		return nil, err
+	}
+
+	rv := reflect.ValueOf(input)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf(
+			"%w, expected an array of items, got %s",
+			ErrFieldMappingDictionaryMalformed,
+			rv.Type(),
+		)
+	}
+
+	result := make(map[string]any, rv.Len())
+
+	var missingFields []MissingFieldError
+
+	for i := range rv.Len() {
+		itemScope := scope.withCurrent(rv.Index(i).Interface())
+
+		rawKey, err := itemScope.search(fm.Key)
+		if err != nil {
+			return nil, fmt.Errorf("%d: failed to evaluate dictionary key: %w", i, err)
+		}
+
+		key, err := castString(rawKey)
+		if err != nil || rawKey == nil {
+			return nil, fmt.Errorf("%d: %w, expected a scalar value, got %v", i, ErrFieldMappingDictionaryKey, rawKey)
+		}
+
+		if _, ok := result[key]; ok {
+			return nil, fmt.Errorf("%d: %w, duplicated key %q", i, ErrFieldMappingDictionaryKey, key)
+		}
+
+		if fm.Value == nil {
+			result[key] = itemScope.current
+
+			continue
+		}
+
+		value, err := evaluateFieldMapping(*fm.Value, itemScope.withProperty(key))
+		if err != nil {
+			if errors.Is(err, errFieldOmitted) {
+				continue
+			}
+
+			var missingErr *MissingFieldsError
+
+			if errors.As(err, &missingErr) {
+				missingFields = append(missingFields, missingErr.withParent(key)...)
+
+				continue
+			}
+
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+
+		result[key] = value
+	}
+
+	if len(missingFields) > 0 {
+		return nil, &MissingFieldsError{Fields: missingFields}
+	}
+
+	return result, nil
+}
+
+func (fm FieldMappingDictionary) errorPolicy() ErrorPolicy {
+	return fm.OnError
+}
+
+func (fm FieldMappingDictionary) nestedFieldMappings() []FieldMapping {
+	if fm.Value == nil {
+		return nil
+	}
+
+	return []FieldMapping{*fm.Value}
+}
+
+// FieldMappingEntries converts an object to an array of key-value entries.
+type FieldMappingEntries struct {
+	// Path is a JMESPath expression to select the object. The current input data is used if nil.
+	Path *string
+	// KeyName is the property name of keys in entries. Defaults to key.
+	KeyName string
+	// ValueName is the property name of values in entries. Defaults to value.
+	ValueName string
+	// Item is the mapping of each entry. The entry is used as is if nil.
+	Item *FieldMapping
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy
+}
+
+var _ FieldMappingInterface = (*FieldMappingEntries)(nil)
+
+// Type returns type of the field mapping entries.
+func (FieldMappingEntries) Type() FieldMappingType {
+	return FieldMappingTypeEntries
+}
+
+// IsZero checks if the field mapping entries is empty.
+// It is never empty because entries without options convert the current input data.
+func (FieldMappingEntries) IsZero() bool {
+	return false
+}
+
+// Equal checks if this instance equals the target value.
+func (fm FieldMappingEntries) Equal(target FieldMappingEntries) bool {
+	return fm.KeyName == target.KeyName &&
+		fm.ValueName == target.ValueName &&
+		fm.OnError == target.OnError &&
+		goutils.EqualComparablePtr(fm.Path, target.Path) &&
+		goutils.EqualPtr(fm.Item, target.Item)
+}
+
+// Evaluate converts the selected object to an array of entries in the order of keys.
+// Keys of maps are sorted, and keys of ordered objects keep their order.
+func (fm FieldMappingEntries) Evaluate(data any) (any, error) {
+	result, err := fm.evaluateScope(newEvaluationScope(data, nil))
+	if errors.Is(err, errFieldOmitted) {
+		return nil, nil
+	}
+
+	return result, err
+}
+
+func (fm FieldMappingEntries) evaluateScope(scope *evaluationScope) (any, error) {
+	input, err := selectPathInput(scope, fm.Path)
+	if err != nil || input == nil {
+		return nil, err
+	}
+
+	keys, values, err := objectEntries(input)
+	if err != nil {
+		return nil, err
+	}
+
+	keyName, valueName := fm.entryNames()
+	results := make([]any, 0, len(keys))
+
+	var missingFields []MissingFieldError
+
+	for i, key := range keys {
+		entry := map[string]any{
+			keyName:   key,
+			valueName: values[i],
+		}
+
+		if fm.Item == nil {
+			results = append(results, entry)
+
+			continue
+		}
+
+		// the key identifies the entry in warnings and errors even if previous entries are skipped.
+		value, err := evaluateFieldMapping(*fm.Item, scope.withCurrent(entry).withProperty(key))
+		if err != nil {
+			if errors.Is(err, errFieldOmitted) {
+				continue
+			}
+
+			var missingErr *MissingFieldsError
+
+			if errors.As(err, &missingErr) {
+				missingFields = append(missingFields, missingErr.withParent(key)...)
+
+				continue
+			}
+
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+
+		results = append(results, value)
+	}
+
+	if len(missingFields) > 0 {
+		return nil, &MissingFieldsError{Fields: missingFields}
+	}
+
+	return results, nil
+}
+
+func (fm FieldMappingEntries) entryNames() (string, string) {
+	keyName, valueName := fm.KeyName, fm.ValueName
+
+	if keyName == "" {
+		keyName = defaultEntryKeyName
+	}
+
+	if valueName == "" {
+		valueName = defaultEntryValueName
+	}
+
+	return keyName, valueName
+}
+
+func (fm FieldMappingEntries) errorPolicy() ErrorPolicy {
+	return fm.OnError
+}
+
+func (fm FieldMappingEntries) nestedFieldMappings() []FieldMapping {
+	if fm.Item == nil {
+		return nil
+	}
+
+	return []FieldMapping{*fm.Item}
+}
+
+// selectPathInput returns the result of the JMESPath expression, or the current data if the path is empty.
+func selectPathInput(scope *evaluationScope, path *string) (any, error) {
+	if path == nil || *path == "" {
+		return scope.current, nil
+	}
+
+	result, err := scope.search(*path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate path: %w", err)
+	}
+
+	return result, nil
+}
+
+// objectEntries returns keys and values of an object. Keys of maps are sorted.
+func objectEntries(input any) ([]string, []any, error) {
+	if oo, ok := input.(*OrderedObject); ok {
+		keys := oo.Keys()
+		values := make([]any, len(keys))
+
+		for i, key := range keys {
+			values[i], _ = oo.Get(key)
+		}
+
+		return keys, values, nil
+	}
+
+	rv := reflect.ValueOf(input)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, nil, fmt.Errorf(
+			"%w, expected an object, got %s",
+			ErrFieldMappingDictionaryMalformed,
+			rv.Type(),
+		)
+	}
+
+	keys := make([]string, 0, rv.Len())
+
+	for _, key := range rv.MapKeys() {
+		keys = append(keys, key.String())
+	}
+
+	slices.Sort(keys)
+
+	values := make([]any, len(keys))
+
+	for i, key := range keys {
+		values[i] = rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key())).Interface()
+	}
+
+	return keys, values, nil
+}
+
+// FieldMappingDictionaryConfig represents configurations for a field mapping which converts an array to an object.
+type FieldMappingDictionaryConfig struct {
+	// Path is a JMESPath expression to select the array. The current input data is used if empty.
+	Path *string `json:"path,omitempty" yaml:"path,omitempty" jsonschema:"description=JMESPath expression to select the array. The current input data is used if empty"`
+	// Key is a JMESPath expression to select the key of each item. Keys must be unique scalar values.
+	Key string `json:"key" yaml:"key" jsonschema:"description=JMESPath expression to select the key of each item. Keys must be unique scalar values"`
+	// Value is the mapping of each item. The item is used as is if empty.
+	Value *FieldMappingConfig `json:"value,omitempty" yaml:"value,omitempty" jsonschema:"description=Mapping of each item. The item is used as is if empty"`
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy `json:"onError,omitempty" yaml:"onError,omitempty" jsonschema:"enum=fail,enum=useDefault,enum=null,enum=omit,default=fail,description=Behavior when the evaluation fails"`
+}
+
+var _ FieldMappingConfigInterface = (*FieldMappingDictionaryConfig)(nil)
+
+// Type returns the type of field mapping config.
+func (FieldMappingDictionaryConfig) Type() FieldMappingType {
+	return FieldMappingTypeDictionary
+}
+
+// IsZero checks if the config is empty.
+func (fm FieldMappingDictionaryConfig) IsZero() bool {
+	return fm.Key == ""
+}
+
+// Equal checks if this instance equals the target value.
+func (fm FieldMappingDictionaryConfig) Equal(target FieldMappingDictionaryConfig) bool {
+	return fm.Key == target.Key &&
+		fm.OnError == target.OnError &&
+		goutils.EqualComparablePtr(fm.Path, target.Path) &&
+		goutils.EqualPtr(fm.Value, target.Value)
+}
+
+// EvaluateEnv converts the config to the field mapping instance with environment variables.
+func (fm FieldMappingDictionaryConfig) EvaluateEnv() (FieldMapping, error) {
+	return fm.Evaluate(goenvconf.GetOSEnv)
+}
+
+// Evaluate converts the config to the field mapping instance.
+func (fm FieldMappingDictionaryConfig) Evaluate(getEnvFunc goenvconf.GetEnvFunc) (FieldMapping, error) {
+	if fm.Key == "" {
+		return FieldMapping{}, fmt.Errorf("%w: key must not be empty", ErrFieldMappingDictionaryMalformed)
+	}
+
+	if fm.OnError != "" {
+		err := fm.OnError.Validate()
+		if err != nil {
+			return FieldMapping{}, err
+		}
+	}
+
+	value, err := evaluateOptionalFieldMapping(fm.Value, getEnvFunc)
+	if err != nil {
+		return FieldMapping{}, fmt.Errorf("value: %w", err)
+	}
+
+	return NewFieldMapping(&FieldMappingDictionary{
+		Path:    fm.Path,
+		Key:     fm.Key,
+		Value:   value,
+		OnError: fm.OnError,
+	}), nil
+}
+
+// FieldMappingEntriesConfig represents configurations for a field mapping which converts an object to an array of key-value entries.
+type FieldMappingEntriesConfig struct {
+	// Path is a JMESPath expression to select the object. The current input data is used if empty.
+	Path *string `json:"path,omitempty" yaml:"path,omitempty" jsonschema:"description=JMESPath expression to select the object. The current input data is used if empty"`
+	// KeyName is the property name of keys in entries. Defaults to key.
+	KeyName string `json:"keyName,omitempty" yaml:"keyName,omitempty" jsonschema:"description=Property name of keys in entries,default=key"`
+	// ValueName is the property name of values in entries. Defaults to value.
+	ValueName string `json:"valueName,omitempty" yaml:"valueName,omitempty" jsonschema:"description=Property name of values in entries,default=value"`
+	// Item is the mapping of each key-value entry. The entry is used as is if empty.
+	Item *FieldMappingConfig `json:"item,omitempty" yaml:"item,omitempty" jsonschema:"description=Mapping of each key-value entry. The entry is used as is if empty"`
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy `json:"onError,omitempty" yaml:"onError,omitempty" jsonschema:"enum=fail,enum=useDefault,enum=null,enum=omit,default=fail,description=Behavior when the evaluation fails"`
+}
+
+var _ FieldMappingConfigInterface = (*FieldMappingEntriesConfig)(nil)
+
+// Type returns the type of field mapping config.
+func (FieldMappingEntriesConfig) Type() FieldMappingType {
+	return FieldMappingTypeEntries
+}
+
+// IsZero checks if the config is empty.
+// It is never empty because entries without options convert the current input data.
+func (FieldMappingEntriesConfig) IsZero() bool {
+	return false
+}
+
+// Equal checks if this instance equals the target value.
+func (fm FieldMappingEntriesConfig) Equal(target FieldMappingEntriesConfig) bool {
+	return fm.KeyName == target.KeyName &&
+		fm.ValueName == target.ValueName &&
+		fm.OnError == target.OnError &&
+		goutils.EqualComparablePtr(fm.Path, target.Path) &&
+		goutils.EqualPtr(fm.Item, target.Item)
+}
+
+// EvaluateEnv converts the config to the field mapping instance with environment variables.
+func (fm FieldMappingEntriesConfig) EvaluateEnv() (FieldMapping, error) {
+	return fm.Evaluate(goenvconf.GetOSEnv)
+}
+
+// Evaluate converts the config to the field mapping instance.
+func (fm FieldMappingEntriesConfig) Evaluate(getEnvFunc goenvconf.GetEnvFunc) (FieldMapping, error) {
+	result := &FieldMappingEntries{
+		Path:      fm.Path,
+		KeyName:   fm.KeyName,
+		ValueName: fm.ValueName,
+		OnError:   fm.OnError,
+	}
+
+	if keyName, valueName := result.entryNames(); keyName == valueName {
+		return FieldMapping{}, fmt.Errorf(
+			"%w: keyName and valueName must be different",
+			ErrFieldMappingDictionaryMalformed,
+		)
+	}
+
+	if fm.OnError != "" {
+		err := fm.OnError.Validate()
+		if err != nil {
+			return FieldMapping{}, err
+		}
+	}
+
+	item, err := evaluateOptionalFieldMapping(fm.Item, getEnvFunc)
+	if err != nil {
+		return FieldMapping{}, fmt.Errorf("item: %w", err)
+	}
+
+	result.Item = item
+
+	return NewFieldMapping(result), nil
+}
+
+// evaluateOptionalFieldMapping converts the config to the field mapping instance if it is set.
+func evaluateOptionalFieldMapping(
+	config *FieldMappingConfig,
+	getEnvFunc goenvconf.GetEnvFunc,
+) (*FieldMapping, error) {
+	if config == nil || config.FieldMappingConfigInterface == nil {
+		return nil, nil
+	}
+
+	result, err := config.Evaluate(getEnvFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
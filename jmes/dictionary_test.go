@@ -0,0 +1,328 @@
+package jmes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+)
+
+func evaluateTestFieldMappingConfig(t *testing.T, rawConfig string) FieldMapping {
+	t.Helper()
+
+	var config FieldMappingConfig
+
+	err := json.Unmarshal([]byte(rawConfig), &config)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	mapping, err := config.Evaluate(goenvconf.GetOSEnv)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	return mapping
+}
+
+// missingFieldProperties returns output properties of missing fields of the error.
+func missingFieldProperties(t *testing.T, err error) []string {
+	t.Helper()
+
+	var missingErr *MissingFieldsError
+	if !errors.As(err, &missingErr) {
+		t.Fatalf("expected MissingFieldsError, got: %v", err)
+	}
+
+	properties := make([]string, len(missingErr.Fields))
+
+	for i, field := range missingErr.Fields {
+		properties[i] = field.Property
+	}
+
+	return properties
+}
+
+func TestFieldMappingDictionary_Evaluate(t *testing.T) {
+	data := map[string]any{
+		"items": []any{
+			map[string]any{"sku": "a", "qty": 1},
+			map[string]any{"sku": "b", "qty": 2},
+		},
+	}
+
+	t.Run("items as values", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{"type": "dictionary", "path": "items", "key": "sku"}`)
+
+		result, err := mapping.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string]any{
+			"a": map[string]any{"sku": "a", "qty": 1},
+			"b": map[string]any{"sku": "b", "qty": 2},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("mapped values", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{
+			"type": "dictionary",
+			"path": "items",
+			"key": "sku",
+			"value": {
+				"type": "object",
+				"properties": {
+					"quantity": { "type": "field", "path": "qty" }
+				}
+			}
+		}`)
+
+		result, err := mapping.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string]any{
+			"a": map[string]any{"quantity": 1},
+			"b": map[string]any{"quantity": 2},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("missing fields of values", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{
+			"type": "dictionary",
+			"path": "items",
+			"key": "sku",
+			"value": {
+				"type": "object",
+				"properties": {
+					"price": { "type": "field", "path": "price", "required": true }
+				}
+			}
+		}`)
+
+		_, err := mapping.Evaluate(data)
+
+		expected := []string{"a.price", "b.price"}
+		if properties := missingFieldProperties(t, err); !reflect.DeepEqual(expected, properties) {
+			t.Errorf("expected missing properties %v, got: %v", expected, properties)
+		}
+	})
+
+	t.Run("duplicated key", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{"type": "dictionary", "path": "items", "key": "'same'"}`)
+
+		_, err := mapping.Evaluate(data)
+		if !errors.Is(err, ErrFieldMappingDictionaryKey) {
+			t.Fatalf("expected ErrFieldMappingDictionaryKey, got: %v", err)
+		}
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{"type": "dictionary", "path": "items", "key": "id"}`)
+
+		_, err := mapping.Evaluate(data)
+		if !errors.Is(err, ErrFieldMappingDictionaryKey) {
+			t.Fatalf("expected ErrFieldMappingDictionaryKey, got: %v", err)
+		}
+	})
+
+	t.Run("not an array", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{"type": "dictionary", "key": "sku"}`)
+
+		_, err := mapping.Evaluate(data)
+		if !errors.Is(err, ErrFieldMappingDictionaryMalformed) {
+			t.Fatalf("expected ErrFieldMappingDictionaryMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("empty key config", func(t *testing.T) {
+		_, err := FieldMappingDictionaryConfig{}.EvaluateEnv()
+		if !errors.Is(err, ErrFieldMappingDictionaryMalformed) {
+			t.Fatalf("expected ErrFieldMappingDictionaryMalformed, got: %v", err)
+		}
+	})
+}
+
+func TestFieldMappingEntries_Evaluate(t *testing.T) {
+	data := map[string]any{
+		"stock": map[string]any{"b": 2, "a": 1},
+	}
+
+	t.Run("default entries", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{"type": "entries", "path": "stock"}`)
+
+		result, err := mapping.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := []any{
+			map[string]any{"key": "a", "value": 1},
+			map[string]any{"key": "b", "value": 2},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("mapped entries", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{
+			"type": "entries",
+			"path": "stock",
+			"keyName": "sku",
+			"valueName": "qty",
+			"item": {
+				"type": "object",
+				"properties": {
+					"sku": { "type": "field", "path": "sku" },
+					"quantity": { "type": "field", "path": "qty" }
+				}
+			}
+		}`)
+
+		result, err := mapping.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := []any{
+			map[string]any{"sku": "a", "quantity": 1},
+			map[string]any{"sku": "b", "quantity": 2},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("missing fields of items", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{
+			"type": "entries",
+			"path": "stock",
+			"item": {
+				"type": "object",
+				"properties": {
+					"price": { "type": "field", "path": "value.price", "required": true }
+				}
+			}
+		}`)
+
+		_, err := mapping.Evaluate(data)
+
+		expected := []string{"a.price", "b.price"}
+		if properties := missingFieldProperties(t, err); !reflect.DeepEqual(expected, properties) {
+			t.Errorf("expected missing properties %v, got: %v", expected, properties)
+		}
+	})
+
+	t.Run("paths of failing items", func(t *testing.T) {
+		prices := map[string]any{
+			"prices": map[string]any{"a": "x", "b": "1", "c": "y"},
+		}
+		rawConfig := `{
+			"type": "entries",
+			"path": "prices",
+			"item": {
+				"type": "object",
+				"onError": "%s",
+				"properties": {
+					"price": { "type": "field", "path": "value", "cast": { "type": "integer" } }
+				}
+			}
+		}`
+
+		mapping := evaluateTestFieldMappingConfig(t, fmt.Sprintf(rawConfig, ErrorPolicyOmit))
+
+		result, warnings, err := NewJMESTemplateTransformer(mapping).TransformWithWarnings(prices)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := []any{map[string]any{"price": int64(1)}}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+
+		properties := make([]string, len(warnings))
+		for i, warning := range warnings {
+			properties[i] = warning.Property
+		}
+
+		if !reflect.DeepEqual([]string{"a", "c"}, properties) {
+			t.Errorf("expected warnings of entries a and c, got: %v", warnings)
+		}
+
+		mapping = evaluateTestFieldMappingConfig(t, fmt.Sprintf(rawConfig, ErrorPolicyFail))
+
+		_, err = mapping.Evaluate(prices)
+		if err == nil || !strings.HasPrefix(err.Error(), "a: price: ") {
+			t.Errorf("expected the error of entry a, got: %v", err)
+		}
+	})
+
+	t.Run("ordered object", func(t *testing.T) {
+		input := NewOrderedObject()
+		input.Set("z", 1)
+		input.Set("a", 2)
+
+		result, err := FieldMappingEntries{}.Evaluate(input)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := []any{
+			map[string]any{"key": "z", "value": 1},
+			map[string]any{"key": "a", "value": 2},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("bare entries", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{"type": "entries"}`)
+		if mapping.IsZero() {
+			t.Fatal("expected bare entries mapping to be non-zero")
+		}
+
+		transformer := NewJMESTemplateTransformer(mapping)
+		if transformer.IsZero() {
+			t.Fatal("expected transformer of bare entries to be non-zero")
+		}
+
+		result, err := transformer.Transform(map[string]any{"a": 1})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := []any{map[string]any{"key": "a", "value": 1}}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("not an object", func(t *testing.T) {
+		_, err := FieldMappingEntries{}.Evaluate([]any{1})
+		if !errors.Is(err, ErrFieldMappingDictionaryMalformed) {
+			t.Fatalf("expected ErrFieldMappingDictionaryMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("conflicting names", func(t *testing.T) {
+		_, err := FieldMappingEntriesConfig{KeyName: "value"}.EvaluateEnv()
+		if !errors.Is(err, ErrFieldMappingDictionaryMalformed) {
+			t.Fatalf("expected ErrFieldMappingDictionaryMalformed, got: %v", err)
+		}
+	})
+}
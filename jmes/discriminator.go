@@ -0,0 +1,192 @@
+package jmes
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hasura/goenvconf"
+	"github.com/relychan/goutils"
+)
+
+var (
+	// ErrFieldMappingDiscriminatorMalformed occurs when the config of a discriminator mapping is invalid.
+	ErrFieldMappingDiscriminatorMalformed = errors.New("field mapping discriminator is malformed")
+	// ErrFieldMappingDiscriminatorUnmatched occurs when the discriminator value does not match any case
+	// and the default case is not set.
+	ErrFieldMappingDiscriminatorUnmatched = errors.New("discriminator value does not match any case")
+)
+
+// FieldMappingDiscriminator chooses a field mapping by the discriminator value of the input data.
+type FieldMappingDiscriminator struct {
+	// Path is a JMESPath expression to select the discriminator value.
+	Path string
+	// Cases are field mappings by the string representation of discriminator values.
+	Cases map[string]FieldMapping
+	// Default is the field mapping to be used if the discriminator value does not match any case.
+	Default *FieldMapping
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy
+}
+
+var _ FieldMappingInterface = (*FieldMappingDiscriminator)(nil)
+
+// Type returns type of the field mapping discriminator.
+func (FieldMappingDiscriminator) Type() FieldMappingType {
+	return FieldMappingTypeDiscriminator
+}
+
+// IsZero checks if the field mapping discriminator is empty.
+func (fm FieldMappingDiscriminator) IsZero() bool {
+	return fm.Path == "" && len(fm.Cases) == 0 && fm.Default == nil
+}
+
+// Equal checks if this instance equals the target value.
+func (fm FieldMappingDiscriminator) Equal(target FieldMappingDiscriminator) bool {
+	return fm.Path == target.Path &&
+		fm.OnError == target.OnError &&
+		goutils.EqualMap(fm.Cases, target.Cases, false) &&
+		goutils.EqualPtr(fm.Default, target.Default)
+}
+
+// Evaluate selects the field mapping by the discriminator value and transforms data with it.
+func (fm FieldMappingDiscriminator) Evaluate(data any) (any, error) {
+	result, err := fm.evaluateScope(newEvaluationScope(data, nil))
+	if errors.Is(err, errFieldOmitted) {
+		return nil, nil
+	}
+
+	return result, err
+}
+
+func (fm FieldMappingDiscriminator) evaluateScope(scope *evaluationScope) (any, error) {
+	value, err := scope.search(fm.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate discriminator path: %w", err)
+	}
+
+	if value != nil {
+		key, err := castString(value)
+		if err == nil {
+			if mapping, ok := fm.Cases[key]; ok {
+				return evaluateFieldMapping(mapping, scope)
+			}
+		}
+	}
+
+	if fm.Default != nil {
+		return evaluateFieldMapping(*fm.Default, scope)
+	}
+
+	return nil, fmt.Errorf("%w: %v (path: %s)", ErrFieldMappingDiscriminatorUnmatched, value, fm.Path)
+}
+
+func (fm FieldMappingDiscriminator) errorPolicy() ErrorPolicy {
+	return fm.OnError
+}
+
+func (fm FieldMappingDiscriminator) nestedFieldMappings() []FieldMapping {
+	keys := make([]string, 0, len(fm.Cases))
+
+	for key := range fm.Cases {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	results := make([]FieldMapping, 0, len(keys)+1)
+
+	for _, key := range keys {
+		results = append(results, fm.Cases[key])
+	}
+
+	if fm.Default != nil {
+		results = append(results, *fm.Default)
+	}
+
+	return results
+}
+
+// FieldMappingDiscriminatorConfig represents configurations for a field mapping which is chosen by a discriminator value.
+type FieldMappingDiscriminatorConfig struct {
+	// Path is a JMESPath expression to select the discriminator value.
+	Path string `json:"path" yaml:"path" jsonschema:"description=JMESPath expression to select the discriminator value"`
+	// Cases are field mappings by discriminator values. Non-string values are matched by their string representation.
+	Cases map[string]FieldMappingConfig `json:"cases" yaml:"cases" jsonschema:"description=Field mappings by discriminator values. Non-string values are matched by their string representation"`
+	// Default is the field mapping to be used if the discriminator value does not match any case.
+	// The evaluation fails if no case matches and the default is not set.
+	Default *FieldMappingConfig `json:"default,omitempty" yaml:"default,omitempty" jsonschema:"description=Field mapping to be used if the discriminator value does not match any case"`
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy `json:"onError,omitempty" yaml:"onError,omitempty" jsonschema:"enum=fail,enum=useDefault,enum=null,enum=omit,default=fail,description=Behavior when the evaluation fails"`
+}
+
+var _ FieldMappingConfigInterface = (*FieldMappingDiscriminatorConfig)(nil)
+
+// Type returns the type of field mapping config.
+func (FieldMappingDiscriminatorConfig) Type() FieldMappingType {
+	return FieldMappingTypeDiscriminator
+}
+
+// IsZero checks if the config is empty.
+func (fm FieldMappingDiscriminatorConfig) IsZero() bool {
+	return fm.Path == "" && len(fm.Cases) == 0 && fm.Default == nil
+}
+
+// Equal checks if this instance equals the target value.
+func (fm FieldMappingDiscriminatorConfig) Equal(target FieldMappingDiscriminatorConfig) bool {
+	return fm.Path == target.Path &&
+		fm.OnError == target.OnError &&
+		goutils.EqualMap(fm.Cases, target.Cases, true) &&
+		goutils.EqualPtr(fm.Default, target.Default)
+}
+
+// EvaluateEnv converts the config to the field mapping instance with environment variables.
+func (fm FieldMappingDiscriminatorConfig) EvaluateEnv() (FieldMapping, error) {
+	return fm.Evaluate(goenvconf.GetOSEnv)
+}
+
+// Evaluate converts the config to the field mapping instance.
+func (fm FieldMappingDiscriminatorConfig) Evaluate(getEnvFunc goenvconf.GetEnvFunc) (FieldMapping, error) {
+	if fm.Path == "" {
+		return FieldMapping{}, fmt.Errorf("%w: path must not be empty", ErrFieldMappingDiscriminatorMalformed)
+	}
+
+	if len(fm.Cases) == 0 {
+		return FieldMapping{}, fmt.Errorf("%w: cases must not be empty", ErrFieldMappingDiscriminatorMalformed)
+	}
+
+	if fm.OnError != "" {
+		err := fm.OnError.Validate()
+		if err != nil {
+			return FieldMapping{}, err
+		}
+	}
+
+	result := &FieldMappingDiscriminator{
+		Path:    fm.Path,
+		Cases:   make(map[string]FieldMapping, len(fm.Cases)),
+		OnError: fm.OnError,
+	}
+
+	for key, caseConfig := range fm.Cases {
+		if caseConfig.FieldMappingConfigInterface == nil {
+			return FieldMapping{}, fmt.Errorf("cases.%s: %w", key, ErrFieldMappingEntryRequired)
+		}
+
+		mapping, err := caseConfig.Evaluate(getEnvFunc)
+		if err != nil {
+			return FieldMapping{}, fmt.Errorf("cases.%s: %w", key, err)
+		}
+
+		result.Cases[key] = mapping
+	}
+
+	defaultMapping, err := evaluateOptionalFieldMapping(fm.Default, getEnvFunc)
+	if err != nil {
+		return FieldMapping{}, fmt.Errorf("default: %w", err)
+	}
+
+	result.Default = defaultMapping
+
+	return NewFieldMapping(result), nil
+}
@@ -0,0 +1,133 @@
+package jmes
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+)
+
+func TestFieldMappingDiscriminatorConfig_Evaluate(t *testing.T) {
+	rawConfig := `{
+		"type": "object",
+		"properties": {
+			"id": { "type": "field", "path": "id" },
+			"body": {
+				"type": "discriminator",
+				"path": "type",
+				"cases": {
+					"created": {
+						"type": "object",
+						"properties": {
+							"name": { "type": "field", "path": "payload.name" }
+						}
+					},
+					"1": { "type": "field", "path": "payload.code" }
+				},
+				"default": { "type": "field", "path": "payload" }
+			}
+		}
+	}`
+
+	var config FieldMappingConfig
+
+	err := json.Unmarshal([]byte(rawConfig), &config)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	mapping, err := config.Evaluate(goenvconf.GetOSEnv)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	testCases := []struct {
+		Name     string
+		Input    map[string]any
+		Expected any
+	}{
+		{
+			Name:     "string case",
+			Input:    map[string]any{"id": 1, "type": "created", "payload": map[string]any{"name": "foo"}},
+			Expected: map[string]any{"id": 1, "body": map[string]any{"name": "foo"}},
+		},
+		{
+			Name:     "number case",
+			Input:    map[string]any{"id": 2, "type": float64(1), "payload": map[string]any{"code": "x"}},
+			Expected: map[string]any{"id": 2, "body": "x"},
+		},
+		{
+			Name:     "default case",
+			Input:    map[string]any{"id": 3, "type": "deleted", "payload": "bar"},
+			Expected: map[string]any{"id": 3, "body": "bar"},
+		},
+		{
+			Name:     "missing discriminator",
+			Input:    map[string]any{"id": 4, "payload": "baz"},
+			Expected: map[string]any{"id": 4, "body": "baz"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := mapping.Evaluate(tc.Input)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if !reflect.DeepEqual(tc.Expected, result) {
+				t.Errorf("expected %v, got: %v", tc.Expected, result)
+			}
+		})
+	}
+
+	t.Run("no matching case", func(t *testing.T) {
+		discriminator := config.Interface().(*FieldMappingObjectConfig).Properties["body"].Interface().(*FieldMappingDiscriminatorConfig)
+		discriminator.Default = nil
+
+		mapping, err := discriminator.EvaluateEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		_, err = mapping.Evaluate(map[string]any{"type": "deleted"})
+		if !errors.Is(err, ErrFieldMappingDiscriminatorUnmatched) {
+			t.Fatalf("expected ErrFieldMappingDiscriminatorUnmatched, got: %v", err)
+		}
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		for _, config := range []FieldMappingDiscriminatorConfig{
+			{Cases: map[string]FieldMappingConfig{"a": {}}},
+			{Path: "type"},
+		} {
+			_, err := config.EvaluateEnv()
+			if !errors.Is(err, ErrFieldMappingDiscriminatorMalformed) {
+				t.Errorf("expected ErrFieldMappingDiscriminatorMalformed, got: %v", err)
+			}
+		}
+	})
+}
+
+func TestFieldMappingDiscriminator_Equal(t *testing.T) {
+	path := "payload"
+	discriminator := FieldMappingDiscriminator{
+		Path: "type",
+		Cases: map[string]FieldMapping{
+			"a": NewFieldMapping(&FieldMappingEntry{Path: &path}),
+		},
+	}
+
+	if !NewFieldMapping(&discriminator).Equal(NewFieldMapping(&discriminator)) {
+		t.Error("expected discriminators to be equal")
+	}
+
+	other := discriminator
+	other.Path = "kind"
+
+	if discriminator.Equal(other) {
+		t.Error("expected discriminators with different paths not to be equal")
+	}
+}
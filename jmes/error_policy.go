@@ -0,0 +1,113 @@
+package jmes
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrorPolicy represents the behavior enum when the evaluation of a field mapping fails.
+type ErrorPolicy string
+
+const (
+	// ErrorPolicyFail returns the error and fails the whole evaluation.
+	ErrorPolicyFail ErrorPolicy = "fail"
+	// ErrorPolicyUseDefault uses the default value of the field entry, or null for other mappings.
+	ErrorPolicyUseDefault ErrorPolicy = "useDefault"
+	// ErrorPolicyNull uses null as the result.
+	ErrorPolicyNull ErrorPolicy = "null"
+	// ErrorPolicyOmit removes the property from the parent object, or the item from the parent array.
+	ErrorPolicyOmit ErrorPolicy = "omit"
+)
+
+var enumValuesErrorPolicy = []ErrorPolicy{
+	ErrorPolicyFail,
+	ErrorPolicyUseDefault,
+	ErrorPolicyNull,
+	ErrorPolicyOmit,
+}
+
+var (
+	errUnsupportedErrorPolicy = errors.New("unsupported error policy")
+	// errFieldOmitted signals the parent mapping to remove the field from the result.
+	errFieldOmitted = errors.New("field is omitted")
+)
+
+// Validate checks if the error policy is valid.
+func (ep ErrorPolicy) Validate() error {
+	if !slices.Contains(enumValuesErrorPolicy, ep) {
+		return fmt.Errorf("%w: %w: %s", ErrFieldMappingEntryMalformed, errUnsupportedErrorPolicy, ep)
+	}
+
+	return nil
+}
+
+// FieldWarning is a non-fatal error of a field mapping which is tolerated by its error policy,
+// or a notice of the evaluation, e.g. [ErrFallbackPathMatched].
+type FieldWarning struct {
+	// Property is the dotted path of the field in the output, empty if the field is the root.
+	Property string
+	// Policy is the error policy which was applied, empty if the warning is a notice.
+	Policy ErrorPolicy
+	// Err is the error of the evaluation.
+	Err error
+}
+
+// Error implements the error interface.
+func (fw FieldWarning) Error() string {
+	if fw.Property == "" {
+		return fw.Err.Error()
+	}
+
+	return fw.Property + ": " + fw.Err.Error()
+}
+
+// Unwrap returns the error of the evaluation.
+func (fw FieldWarning) Unwrap() error {
+	return fw.Err
+}
+
+// errorPolicyFieldMapping is implemented by field mappings which can tolerate errors of the evaluation.
+type errorPolicyFieldMapping interface {
+	errorPolicy() ErrorPolicy
+}
+
+// recoverFieldMapping applies the error policy of the field mapping to the evaluation error.
+// Tolerated errors are recorded as warnings of the scope.
+func recoverFieldMapping(fm FieldMapping, scope *evaluationScope, err error) (any, error) {
+	if errors.Is(err, errFieldOmitted) {
+		return nil, err
+	}
+
+	epm, ok := fm.FieldMappingInterface.(errorPolicyFieldMapping)
+	if !ok {
+		return nil, err
+	}
+
+	policy := epm.errorPolicy()
+	if policy == "" || policy == ErrorPolicyFail {
+		return nil, err
+	}
+
+	scope.warn(policy, err)
+
+	switch policy {
+	case ErrorPolicyOmit:
+		return nil, errFieldOmitted
+	case ErrorPolicyUseDefault:
+		switch entry := fm.FieldMappingInterface.(type) {
+		case FieldMappingEntry:
+			return entry.Default, nil
+		case *FieldMappingEntry:
+			return entry.Default, nil
+		case FieldMappingEntryString:
+			return entry.defaultValue(), nil
+		case *FieldMappingEntryString:
+			return entry.defaultValue(), nil
+		default:
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,193 @@
+package jmes
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestErrorPolicy_Validate(t *testing.T) {
+	for _, ep := range enumValuesErrorPolicy {
+		if err := ep.Validate(); err != nil {
+			t.Errorf("expected no error for %s, got: %v", ep, err)
+		}
+	}
+
+	if err := ErrorPolicy("ignore").Validate(); !errors.Is(err, ErrFieldMappingEntryMalformed) {
+		t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+	}
+}
+
+func TestFieldWarning_Error(t *testing.T) {
+	warning := FieldWarning{Property: "a.b", Policy: ErrorPolicyNull, Err: ErrFieldMappingEntryMalformed}
+
+	if warning.Error() != "a.b: "+ErrFieldMappingEntryMalformed.Error() {
+		t.Errorf("unexpected error message: %s", warning.Error())
+	}
+
+	if !errors.Is(warning, ErrFieldMappingEntryMalformed) {
+		t.Error("expected the warning to wrap the evaluation error")
+	}
+}
+
+func TestJMESTemplateTransformer_TransformWithWarnings(t *testing.T) {
+	countPath := "count"
+	namePath := "name"
+	itemsPath := "items"
+	invalidCast := &FieldCast{Type: CastTypeInteger}
+
+	item := NewFieldMapping(FieldMappingObject{
+		OnError: ErrorPolicyOmit,
+		Properties: map[string]FieldMapping{
+			"count": NewFieldMapping(FieldMappingEntry{Path: &countPath, Cast: invalidCast}),
+		},
+	})
+
+	template := NewFieldMapping(FieldMappingObject{
+		Properties: map[string]FieldMapping{
+			"fail": NewFieldMapping(FieldMappingEntry{Path: &countPath, Cast: invalidCast}),
+			"useDefault": NewFieldMapping(FieldMappingEntry{
+				Path:    &countPath,
+				Cast:    invalidCast,
+				Default: int64(0),
+				OnError: ErrorPolicyUseDefault,
+			}),
+			"null": NewFieldMapping(FieldMappingEntryString{
+				Path:    &itemsPath,
+				OnError: ErrorPolicyNull,
+			}),
+			"omit": NewFieldMapping(FieldMappingEntry{
+				Path:    &countPath,
+				Cast:    invalidCast,
+				OnError: ErrorPolicyOmit,
+			}),
+			"name": NewFieldMapping(FieldMappingEntry{Path: &namePath}),
+			"items": NewFieldMapping(&FieldMappingRef{
+				Name:       "item",
+				Path:       &itemsPath,
+				Items:      true,
+				definition: &item,
+			}),
+		},
+	})
+
+	data := map[string]any{
+		"name":  "foo",
+		"count": "many",
+		"items": []any{
+			map[string]any{"count": "1"},
+			map[string]any{"count": "x"},
+		},
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		_, _, err := NewJMESTemplateTransformer(template).TransformWithWarnings(data)
+		if !errors.Is(err, ErrFieldMappingEntryMalformed) {
+			t.Fatalf("expected ErrFieldMappingEntryMalformed, got: %v", err)
+		}
+	})
+
+	delete(template.Interface().(FieldMappingObject).Properties, "fail")
+
+	transformer := NewJMESTemplateTransformer(template)
+
+	result, warnings, err := transformer.TransformWithWarnings(data)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := map[string]any{
+		"useDefault": int64(0),
+		"null":       nil,
+		"name":       "foo",
+		"items": []any{
+			map[string]any{"count": int64(1)},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("expected %v, got: %v", expected, result)
+	}
+
+	properties := make([]string, len(warnings))
+
+	for i, warning := range warnings {
+		properties[i] = warning.Property
+	}
+
+	expectedProperties := []string{"items.1", "null", "omit", "useDefault"}
+	if !reflect.DeepEqual(expectedProperties, properties) {
+		t.Errorf("expected warnings of %v, got: %v", expectedProperties, warnings)
+	}
+
+	t.Run("discard warnings", func(t *testing.T) {
+		result, err := transformer.Transform(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("omit root", func(t *testing.T) {
+		result, err := NewJMESTemplateTransformer(item).Transform(map[string]any{"count": "x"})
+		if err != nil || result != nil {
+			t.Fatalf("expected null without error, got: %v, %v", result, err)
+		}
+	})
+}
+
+func TestJMESTemplateTransformer_TransformWithWarnings_FallbackPath(t *testing.T) {
+	namePath := "name"
+	template := NewFieldMapping(FieldMappingObject{
+		Properties: map[string]FieldMapping{
+			"primary": NewFieldMapping(FieldMappingEntry{Path: &namePath, Paths: []string{"alias"}}),
+			"fallback": NewFieldMapping(FieldMappingEntry{
+				Path:  &namePath,
+				Paths: []string{"nickname", "alias"},
+			}),
+		},
+	})
+
+	_, warnings, err := NewJMESTemplateTransformer(template).
+		TransformWithWarnings(map[string]any{"alias": "foo"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	messages := make([]string, len(warnings))
+
+	for i, warning := range warnings {
+		messages[i] = warning.Error()
+	}
+
+	expectedMessages := []string{
+		"fallback: fallback path matched: alias",
+		"primary: fallback path matched: alias",
+	}
+	if !reflect.DeepEqual(expectedMessages, messages) {
+		t.Fatalf("expected warnings %v, got: %v", expectedMessages, messages)
+	}
+
+	for _, warning := range warnings {
+		if !errors.Is(warning, ErrFallbackPathMatched) {
+			t.Errorf("expected warning to be ErrFallbackPathMatched, got: %v", warning)
+		}
+
+		if warning.Policy != "" {
+			t.Errorf("expected no error policy, got: %s", warning.Policy)
+		}
+	}
+
+	_, warnings, err = NewJMESTemplateTransformer(template).
+		TransformWithWarnings(map[string]any{"name": "foo", "alias": "bar"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("expected no warning if the primary path matches, got: %v", warnings)
+	}
+}
@@ -0,0 +1,94 @@
+package jmes
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrRequiredFieldMissing occurs when a required field resolves to nothing.
+	ErrRequiredFieldMissing = errors.New("required field is missing")
+	// ErrFallbackPathMatched is the warning when the primary path of a field resolves to nothing and a fallback path is used.
+	ErrFallbackPathMatched = errors.New("fallback path matched")
+)
+
+// MissingFieldError describes a required field whose JMESPath expression resolves to nothing.
+type MissingFieldError struct {
+	// Path is the JMESPath expression of the field.
+	Path string
+	// Property is the dotted path of the field in the output, empty if the field is the root.
+	Property string
+	// Message is the custom error message of the field.
+	Message string
+}
+
+// Error implements the error interface.
+func (mfe MissingFieldError) Error() string {
+	var sb strings.Builder
+
+	if mfe.Property != "" {
+		sb.WriteString(mfe.Property)
+		sb.WriteString(": ")
+	}
+
+	if mfe.Message != "" {
+		sb.WriteString(mfe.Message)
+	} else {
+		sb.WriteString(ErrRequiredFieldMissing.Error())
+	}
+
+	sb.WriteString(" (path: ")
+	sb.WriteString(mfe.Path)
+	sb.WriteString(")")
+
+	return sb.String()
+}
+
+// Unwrap returns the wrapped sentinel error.
+func (MissingFieldError) Unwrap() error {
+	return ErrRequiredFieldMissing
+}
+
+// MissingFieldsError collects all required fields which are missing in one evaluation.
+type MissingFieldsError struct {
+	Fields []MissingFieldError
+}
+
+// Error implements the error interface.
+func (mfe *MissingFieldsError) Error() string {
+	messages := make([]string, len(mfe.Fields))
+
+	for i, field := range mfe.Fields {
+		messages[i] = field.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the list of missing field errors.
+func (mfe *MissingFieldsError) Unwrap() []error {
+	errs := make([]error, len(mfe.Fields))
+
+	for i, field := range mfe.Fields {
+		errs[i] = field
+	}
+
+	return errs
+}
+
+// withParent returns missing field errors with the property path prefixed by the parent key.
+func (mfe *MissingFieldsError) withParent(key string) []MissingFieldError {
+	results := make([]MissingFieldError, len(mfe.Fields))
+
+	for i, field := range mfe.Fields {
+		if field.Property == "" {
+			field.Property = key
+		} else {
+			field.Property = key + "." + field.Property
+		}
+
+		results[i] = field
+	}
+
+	return results
+}
@@ -0,0 +1,57 @@
+package jmes
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMissingFieldError_Error(t *testing.T) {
+	t.Run("default message", func(t *testing.T) {
+		err := MissingFieldError{Path: "user.id", Property: "userId"}
+		expected := "userId: required field is missing (path: user.id)"
+
+		if err.Error() != expected {
+			t.Errorf("expected error to be %q, got: %q", expected, err.Error())
+		}
+	})
+
+	t.Run("custom message", func(t *testing.T) {
+		err := MissingFieldError{Path: "user.id", Message: "user id is required"}
+		expected := "user id is required (path: user.id)"
+
+		if err.Error() != expected {
+			t.Errorf("expected error to be %q, got: %q", expected, err.Error())
+		}
+	})
+
+	t.Run("unwrap", func(t *testing.T) {
+		err := MissingFieldError{Path: "user.id"}
+
+		if !errors.Is(err, ErrRequiredFieldMissing) {
+			t.Error("expected error to wrap ErrRequiredFieldMissing")
+		}
+	})
+}
+
+func TestMissingFieldsError(t *testing.T) {
+	err := &MissingFieldsError{
+		Fields: []MissingFieldError{
+			{Path: "id", Property: "id"},
+			{Path: "name", Property: "user.name"},
+		},
+	}
+
+	expected := "id: required field is missing (path: id); user.name: required field is missing (path: name)"
+	if err.Error() != expected {
+		t.Errorf("expected error to be %q, got: %q", expected, err.Error())
+	}
+
+	if !errors.Is(err, ErrRequiredFieldMissing) {
+		t.Error("expected error to wrap ErrRequiredFieldMissing")
+	}
+
+	fields := err.withParent("data")
+	if fields[0].Property != "data.id" || fields[1].Property != "data.user.name" {
+		t.Errorf("expected properties to be prefixed, got: %v", fields)
+	}
+}
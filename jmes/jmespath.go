@@ -1,18 +1,43 @@
 // Package jmes implements the transform template using JMESPath templates.
 package jmes
 
-import "github.com/relychan/gotransform/transformtypes"
+import (
+	"errors"
+
+	"github.com/relychan/gotransform/transformtypes"
+	"github.com/relychan/goutils"
+)
 
 // JMESTemplateTransformer implements the transform template using JMESPath templates.
 type JMESTemplateTransformer struct {
-	template FieldMapping
+	template  FieldMapping
+	variables map[string]any
+}
+
+// JMESTemplateTransformerOption is the function to configure a JMESTemplateTransformer.
+type JMESTemplateTransformerOption func(*JMESTemplateTransformer)
+
+// WithVariables sets variables which are addressable with $vars in JMESPath expressions of the template.
+func WithVariables(variables map[string]any) JMESTemplateTransformerOption {
+	return func(jtt *JMESTemplateTransformer) {
+		jtt.variables = variables
+	}
 }
 
 // NewJMESTemplateTransformer creates a new JMESTemplateTransformer instance.
-func NewJMESTemplateTransformer(template FieldMapping) *JMESTemplateTransformer {
-	return &JMESTemplateTransformer{
+func NewJMESTemplateTransformer(
+	template FieldMapping,
+	options ...JMESTemplateTransformerOption,
+) *JMESTemplateTransformer {
+	result := &JMESTemplateTransformer{
 		template: template,
 	}
+
+	for _, option := range options {
+		option(result)
+	}
+
+	return result
 }
 
 // Type returns the transform template type of this instance.
@@ -26,11 +51,37 @@ func (jtt JMESTemplateTransformer) IsZero() bool {
 }
 
 // Transform processes and injects data into the template to transform data.
+// The input data is addressable with $root in nested mappings.
+// Errors which are tolerated by error policies of fields are discarded.
 func (jtt JMESTemplateTransformer) Transform(data any) (any, error) {
-	return jtt.template.Evaluate(data)
+	return jtt.transform(newEvaluationScope(data, jtt.variables))
+}
+
+// TransformWithWarnings transforms data like [JMESTemplateTransformer.Transform],
+// and returns errors which are tolerated by error policies of fields as warnings alongside the result.
+// Fields whose values are found by fallback paths are also reported with [ErrFallbackPathMatched].
+func (jtt JMESTemplateTransformer) TransformWithWarnings(data any) (any, []FieldWarning, error) {
+	var warnings []FieldWarning
+
+	scope := newEvaluationScope(data, jtt.variables)
+	scope.warnings = &warnings
+
+	result, err := jtt.transform(scope)
+
+	return result, warnings, err
+}
+
+func (jtt JMESTemplateTransformer) transform(scope *evaluationScope) (any, error) {
+	result, err := evaluateFieldMapping(jtt.template, scope)
+	if errors.Is(err, errFieldOmitted) {
+		return nil, nil
+	}
+
+	return result, err
 }
 
 // Equal checks if this instance equals the target value.
 func (jtt JMESTemplateTransformer) Equal(target JMESTemplateTransformer) bool {
-	return jtt.template.Equal(target.template)
+	return jtt.template.Equal(target.template) &&
+		goutils.DeepEqual(jtt.variables, target.variables, true)
 }
@@ -0,0 +1,127 @@
+package jmes
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// LookupFallback represents the behavior enum when a value is not found in the lookup table.
+type LookupFallback string
+
+const (
+	// LookupFallbackPassthrough keeps the original value.
+	LookupFallbackPassthrough LookupFallback = "passthrough"
+	// LookupFallbackDefault uses the default value of the field.
+	LookupFallbackDefault LookupFallback = "default"
+	// LookupFallbackError returns an error.
+	LookupFallbackError LookupFallback = "error"
+)
+
+var enumValuesLookupFallback = []LookupFallback{
+	LookupFallbackPassthrough,
+	LookupFallbackDefault,
+	LookupFallbackError,
+}
+
+var (
+	// ErrLookupValueNotFound occurs when a value is not found in the lookup table and the fallback is error.
+	ErrLookupValueNotFound = errors.New("value is not found in the lookup table")
+	// ErrLookupTableUndefined occurs when a lookup references an unknown table of the transformer config.
+	ErrLookupTableUndefined = errors.New("lookup table is not defined")
+
+	errLookupTableValuesExclusive = errors.New("either table or values of the lookup is required")
+	errUnsupportedLookupFallback  = errors.New("unsupported lookup fallback")
+)
+
+// FieldLookup translates the found value of a field, e.g. upstream status codes to enum strings.
+// Values are matched by their string representation.
+type FieldLookup struct {
+	// Table is the name of a lookup table of the transformer config.
+	Table string `json:"table,omitempty" yaml:"table,omitempty" jsonschema:"description=Name of a lookup table of the transformer config"`
+	// Values is the inline lookup table.
+	Values map[string]any `json:"values,omitempty" yaml:"values,omitempty" jsonschema:"description=Inline lookup table"`
+	// Fallback is the behavior when the value is not found in the lookup table. Defaults to passthrough.
+	Fallback LookupFallback `json:"fallback,omitempty" yaml:"fallback,omitempty" jsonschema:"enum=passthrough,enum=default,enum=error,default=passthrough,description=Behavior when the value is not found in the lookup table"`
+
+	// table is the resolved named lookup table.
+	table map[string]any
+}
+
+// Validate checks if the lookup config is valid.
+func (fl FieldLookup) Validate() error {
+	if (fl.Table == "") == (fl.Values == nil) {
+		return fmt.Errorf("%w: %w", ErrFieldMappingEntryMalformed, errLookupTableValuesExclusive)
+	}
+
+	if fl.Fallback != "" && !slices.Contains(enumValuesLookupFallback, fl.Fallback) {
+		return fmt.Errorf(
+			"%w: %w: %s",
+			ErrFieldMappingEntryMalformed,
+			errUnsupportedLookupFallback,
+			fl.Fallback,
+		)
+	}
+
+	return nil
+}
+
+// Lookup translates the value with the lookup table.
+// It returns null if the value is not found and the fallback is default, so the default value is used.
+func (fl FieldLookup) Lookup(value any) (any, error) {
+	values := fl.Values
+
+	if fl.Table != "" {
+		if fl.table == nil {
+			return nil, fmt.Errorf("%w: %s", ErrLookupTableUndefined, fl.Table)
+		}
+
+		values = fl.table
+	}
+
+	key, err := castString(value)
+	if err == nil {
+		if result, ok := values[key]; ok {
+			return result, nil
+		}
+	}
+
+	switch fl.Fallback {
+	case LookupFallbackDefault:
+		return nil, nil
+	case LookupFallbackError:
+		return nil, fmt.Errorf("%w: %v", ErrLookupValueNotFound, value)
+	default:
+		return value, nil
+	}
+}
+
+// bind resolves the named lookup table.
+func (fl *FieldLookup) bind(tables map[string]map[string]any) error {
+	if fl == nil || fl.Table == "" {
+		return nil
+	}
+
+	table, ok := tables[fl.Table]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrLookupTableUndefined, fl.Table)
+	}
+
+	fl.table = table
+
+	return nil
+}
+
+// bindLookupTables resolves named lookup tables of field entries which are reachable from the field mapping.
+func bindLookupTables(mapping FieldMapping, tables map[string]map[string]any) error {
+	return walkFieldMappings(mapping, func(mapping FieldMapping) error {
+		switch fm := mapping.FieldMappingInterface.(type) {
+		case FieldMappingEntry:
+			return fm.Lookup.bind(tables)
+		case *FieldMappingEntry:
+			return fm.Lookup.bind(tables)
+		default:
+			return nil
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package jmes
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+)
+
+func TestFieldLookup_Validate(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Lookup FieldLookup
+		Valid  bool
+	}{
+		{Name: "inline values", Lookup: FieldLookup{Values: map[string]any{"A": "active"}}, Valid: true},
+		{Name: "named table", Lookup: FieldLookup{Table: "status", Fallback: LookupFallbackError}, Valid: true},
+		{Name: "empty", Lookup: FieldLookup{}},
+		{Name: "both table and values", Lookup: FieldLookup{Table: "status", Values: map[string]any{}}},
+		{Name: "invalid fallback", Lookup: FieldLookup{Table: "status", Fallback: "unknown"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Lookup.Validate()
+			if tc.Valid && err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if !tc.Valid && !errors.Is(err, ErrFieldMappingEntryMalformed) {
+				t.Fatalf("expected ErrFieldMappingEntryMalformed, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestFieldLookup_Lookup(t *testing.T) {
+	values := map[string]any{"A": "active", "1": "one", "true": "yes"}
+
+	testCases := []struct {
+		Name     string
+		Fallback LookupFallback
+		Input    any
+		Expected any
+		Error    error
+	}{
+		{Name: "found", Input: "A", Expected: "active"},
+		{Name: "found number", Input: float64(1), Expected: "one"},
+		{Name: "found boolean", Input: true, Expected: "yes"},
+		{Name: "passthrough", Input: "P", Expected: "P"},
+		{Name: "passthrough non-scalar", Input: []any{"A"}, Expected: []any{"A"}},
+		{Name: "default", Fallback: LookupFallbackDefault, Input: "P", Expected: nil},
+		{Name: "error", Fallback: LookupFallbackError, Input: "P", Error: ErrLookupValueNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			lookup := FieldLookup{Values: values, Fallback: tc.Fallback}
+
+			result, err := lookup.Lookup(tc.Input)
+			if tc.Error != nil {
+				if !errors.Is(err, tc.Error) {
+					t.Fatalf("expected error %v, got: %v", tc.Error, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if !reflect.DeepEqual(tc.Expected, result) {
+				t.Errorf("expected %v, got: %v", tc.Expected, result)
+			}
+		})
+	}
+
+	t.Run("unbound table", func(t *testing.T) {
+		_, err := FieldLookup{Table: "status"}.Lookup("A")
+		if !errors.Is(err, ErrLookupTableUndefined) {
+			t.Fatalf("expected ErrLookupTableUndefined, got: %v", err)
+		}
+	})
+}
+
+func TestJMESTransformerConfig_LookupTables(t *testing.T) {
+	path := "status"
+	defaultStatus := "unknown"
+	defaultValue := goenvconf.NewEnvAnyValue(defaultStatus)
+	config := JMESTransformerConfig{
+		Template: NewFieldMappingConfig(&FieldMappingObjectConfig{
+			Properties: map[string]FieldMappingConfig{
+				"status": NewFieldMappingConfig(&FieldMappingEntryConfig{
+					Path:    &path,
+					Default: &defaultValue,
+					Lookup: &FieldLookup{
+						Table:    "status",
+						Fallback: LookupFallbackDefault,
+					},
+				}),
+				"item": NewFieldMappingConfig(&FieldMappingRefConfig{Ref: "item"}),
+			},
+		}),
+		Definitions: map[string]FieldMappingConfig{
+			"item": NewFieldMappingConfig(&FieldMappingEntryConfig{
+				Path:   &path,
+				Lookup: &FieldLookup{Table: "status"},
+			}),
+		},
+		LookupTables: map[string]map[string]any{
+			"status": {"A": "active", "I": "inactive"},
+		},
+	}
+
+	mapping, err := config.Evaluate(goenvconf.GetOSEnv)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	for input, expected := range map[string]map[string]any{
+		"A": {"status": "active", "item": "active"},
+		"P": {"status": defaultStatus, "item": "P"},
+	} {
+		result, err := mapping.Evaluate(map[string]any{"status": input})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	}
+
+	t.Run("undefined table", func(t *testing.T) {
+		config.LookupTables = nil
+
+		_, err := config.Evaluate(goenvconf.GetOSEnv)
+		if !errors.Is(err, ErrLookupTableUndefined) {
+			t.Fatalf("expected ErrLookupTableUndefined, got: %v", err)
+		}
+	})
+}
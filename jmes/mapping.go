@@ -4,8 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 
-	"github.com/jmespath-community/go-jmespath"
 	"github.com/relychan/goutils"
 )
 
@@ -15,6 +16,15 @@ type FieldMappingType string
 const (
 	FieldMappingTypeField  FieldMappingType = "field"
 	FieldMappingTypeObject FieldMappingType = "object"
+	FieldMappingTypeRef    FieldMappingType = "ref"
+	// FieldMappingTypeDiscriminator chooses a field mapping by the discriminator value of the input data.
+	FieldMappingTypeDiscriminator FieldMappingType = "discriminator"
+	// FieldMappingTypeDictionary converts an array to an object whose keys are derived from each item.
+	FieldMappingTypeDictionary FieldMappingType = "dictionary"
+	// FieldMappingTypeEntries converts an object to an array of key-value entries.
+	FieldMappingTypeEntries FieldMappingType = "entries"
+	// FieldMappingTypeTemplate renders a string from the input data with a Go template.
+	FieldMappingTypeTemplate FieldMappingType = "template"
 )
 
 var (
@@ -23,6 +33,9 @@ var (
 	ErrFieldMappingEntryMalformed  = errors.New("field mapping entry is malformed")
 	ErrFieldMappingEntryRequired   = errors.New("field mapping entry must not be empty")
 	ErrFieldMappingObjectRequired  = errors.New("field mapping object must not be null")
+	ErrFieldMappingObjectMalformed = errors.New("field mapping object is malformed")
+	// ErrFieldMappingPropertyConflict occurs when two property keys of an object mapping resolve to the same output path.
+	ErrFieldMappingPropertyConflict = errors.New("field mapping property conflicts with another property")
 )
 
 // FieldMappingInterface abstracts a field mapping interface.
@@ -33,6 +46,51 @@ type FieldMappingInterface interface {
 	Evaluate(data any) (any, error)
 }
 
+// fieldMappingContainer is implemented by field mappings which contain nested field mappings.
+type fieldMappingContainer interface {
+	nestedFieldMappings() []FieldMapping
+}
+
+// walkFieldMappings calls the visit function with every field mapping which is reachable from the field mapping.
+// Definitions of references are visited once.
+func walkFieldMappings(mapping FieldMapping, visit func(mapping FieldMapping) error) error {
+	visited := map[*FieldMapping]bool{}
+
+	var walk func(mapping FieldMapping) error
+
+	walk = func(mapping FieldMapping) error {
+		err := visit(mapping)
+		if err != nil {
+			return err
+		}
+
+		switch fm := mapping.FieldMappingInterface.(type) {
+		case *FieldMappingRef:
+			if fm.definition == nil || visited[fm.definition] {
+				return nil
+			}
+
+			visited[fm.definition] = true
+
+			err := walk(*fm.definition)
+			if err != nil {
+				return fmt.Errorf("definitions.%s: %w", fm.Name, err)
+			}
+		case fieldMappingContainer:
+			for _, child := range fm.nestedFieldMappings() {
+				err := walk(child)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	return walk(mapping)
+}
+
 // FieldMapping is a wrapper of a field mapping interface to evaluate data.
 type FieldMapping struct {
 	FieldMappingInterface
@@ -74,6 +132,26 @@ func (fm FieldMapping) Equal(target FieldMapping) bool {
 		return goutils.DeepEqual(fmi, target.FieldMappingInterface, true)
 	case *FieldMappingObject:
 		return goutils.DeepEqual(fmi, target.FieldMappingInterface, true)
+	case *FieldMappingRef:
+		targetRef, ok := target.FieldMappingInterface.(*FieldMappingRef)
+
+		return ok && fmi.Equal(*targetRef)
+	case *FieldMappingDiscriminator:
+		targetDiscriminator, ok := target.FieldMappingInterface.(*FieldMappingDiscriminator)
+
+		return ok && fmi.Equal(*targetDiscriminator)
+	case *FieldMappingDictionary:
+		targetDictionary, ok := target.FieldMappingInterface.(*FieldMappingDictionary)
+
+		return ok && fmi.Equal(*targetDictionary)
+	case *FieldMappingEntries:
+		targetEntries, ok := target.FieldMappingInterface.(*FieldMappingEntries)
+
+		return ok && fmi.Equal(*targetEntries)
+	case *FieldMappingTemplate:
+		targetTemplate, ok := target.FieldMappingInterface.(*FieldMappingTemplate)
+
+		return ok && fmi.Equal(*targetTemplate)
 	default:
 		return false
 	}
@@ -83,8 +161,24 @@ func (fm FieldMapping) Equal(target FieldMapping) bool {
 type FieldMappingEntry struct {
 	// Path is a JMESPath expression to find a value in the input data.
 	Path *string
+	// Paths are fallback JMESPath expressions which are evaluated in order if the previous expression resolves to nothing.
+	Paths []string
 	// Default value to be used when no value is found when looking up the value using the path.
 	Default any
+	// NullPolicy is the condition to use the default value. Defaults to defaultOnNull.
+	NullPolicy NullPolicy
+	// Required makes the evaluation fail instead of using the default value, e.g. if the path resolves to nothing.
+	Required bool
+	// ErrorMessage is the custom error message when the required field is missing.
+	ErrorMessage string
+	// Cast converts the found value to the target type.
+	Cast *FieldCast
+	// ValueType is the expected type of the result. Any type is accepted if empty.
+	ValueType ValueType
+	// Lookup translates the found value with a lookup table.
+	Lookup *FieldLookup
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy
 }
 
 var _ FieldMappingInterface = (*FieldMappingEntry)(nil)
@@ -96,40 +190,161 @@ func (FieldMappingEntry) Type() FieldMappingType {
 
 // IsZero checks if the field mapping entry is empty (zero-valued).
 func (fm FieldMappingEntry) IsZero() bool {
-	return (fm.Path == nil || *fm.Path == "") && fm.Default == nil
+	return (fm.Path == nil || *fm.Path == "") && len(fm.Paths) == 0 && fm.Default == nil
 }
 
 // Equal checks if this instance equals the target value.
 func (fm FieldMappingEntry) Equal(target FieldMappingEntry) bool {
 	return goutils.EqualComparablePtr(fm.Path, target.Path) &&
-		goutils.DeepEqual(fm.Default, target.Default, false)
+		slices.Equal(fm.Paths, target.Paths) &&
+		goutils.DeepEqual(fm.Default, target.Default, false) &&
+		fm.NullPolicy == target.NullPolicy &&
+		fm.Required == target.Required &&
+		fm.ErrorMessage == target.ErrorMessage &&
+		goutils.DeepEqual(fm.Cast, target.Cast, false) &&
+		fm.ValueType == target.ValueType &&
+		goutils.DeepEqual(fm.Lookup, target.Lookup, false) &&
+		fm.OnError == target.OnError
 }
 
 // Evaluate validates and transforms data with the specified JMES path.
+// The first non-null result of the path and fallback paths is used.
+// The found value is translated with the lookup table and cast to the target type if configured, before falling back to the default value.
 func (fm FieldMappingEntry) Evaluate(data any) (any, error) {
-	if fm.Path != nil {
-		result := data
+	return fm.evaluateScope(newEvaluationScope(data, nil))
+}
 
-		if *fm.Path != "" {
-			var err error
+func (fm FieldMappingEntry) evaluateScope(scope *evaluationScope) (any, error) {
+	result, err := fm.evaluate(scope)
+	if err != nil {
+		return nil, err
+	}
 
-			result, err = jmespath.Search(*fm.Path, data)
-			if err != nil {
-				return nil, fmt.Errorf("failed to evaluate mapping entry: %w", err)
-			}
+	if fm.ValueType != "" {
+		err := fm.ValueType.Check(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (fm FieldMappingEntry) errorPolicy() ErrorPolicy {
+	return fm.OnError
+}
+
+func (fm FieldMappingEntry) evaluate(scope *evaluationScope) (any, error) {
+	result, present, err := fm.search(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if fm.NullPolicy.useDefault(result, present) {
+		if fm.Required {
+			return nil, fm.missingError()
+		}
+
+		return fm.Default, nil
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	if fm.Lookup != nil {
+		result, err = fm.Lookup.Lookup(result)
+		if err != nil {
+			return nil, err
+		}
+
+		if result == nil {
+			return fm.Default, nil
+		}
+	}
+
+	if fm.Cast != nil {
+		result, err = fm.Cast.Cast(result)
+		if err != nil {
+			return nil, err
+		}
+
+		if result == nil {
+			return fm.Default, nil
+		}
+	}
+
+	return result, nil
+}
+
+// search returns the first non-null result of the path and fallback paths.
+// It also reports if any path is present in the input data when the null policy keeps explicit nulls.
+// The path is recorded as a warning of the scope if the result is found by a fallback path.
+func (fm FieldMappingEntry) search(scope *evaluationScope) (any, bool, error) {
+	var present bool
+
+	for i, path := range fm.searchPaths() {
+		if path == "" {
+			return scope.current, true, nil
+		}
+
+		result, err := scope.search(path)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to evaluate mapping entry: %w", err)
 		}
 
 		if result != nil {
-			return result, nil
+			if i > 0 {
+				scope.warn("", fmt.Errorf("%w: %s", ErrFallbackPathMatched, path))
+			}
+
+			return result, true, nil
 		}
+
+		if fm.NullPolicy == NullPolicyDefaultOnMissing && !present {
+			present, err = scope.exists(path)
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to evaluate mapping entry: %w", err)
+			}
+		}
+	}
+
+	return nil, present, nil
+}
+
+func (fm FieldMappingEntry) searchPaths() []string {
+	if fm.Path == nil {
+		return fm.Paths
 	}
 
-	return fm.Default, nil
+	return append([]string{*fm.Path}, fm.Paths...)
+}
+
+func (fm FieldMappingEntry) missingError() *MissingFieldsError {
+	return &MissingFieldsError{
+		Fields: []MissingFieldError{
+			{
+				Path:    strings.Join(fm.searchPaths(), ", "),
+				Message: fm.ErrorMessage,
+			},
+		},
+	}
 }
 
 // FieldMappingObject is the entry to lookup object values with the specified JMES path.
 type FieldMappingObject struct {
-	Properties map[string]FieldMapping `json:"properties" yaml:"properties"`
+	// Spread copies all keys of the selected object into the result before evaluating properties.
+	Spread     *FieldMappingSpread     `json:"spread,omitempty" yaml:"spread,omitempty"`
+	Properties map[string]FieldMapping `json:"properties"       yaml:"properties"`
+	// Keys is the evaluation order of properties. Properties which are not listed are evaluated afterwards in sorted order.
+	Keys []string `json:"keys,omitempty" yaml:"keys,omitempty"`
+	// Ordered returns an [OrderedObject] which keeps the order of keys instead of a map.
+	Ordered bool `json:"ordered,omitempty" yaml:"ordered,omitempty"`
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy `json:"onError,omitempty" yaml:"onError,omitempty"`
+
+	// orderedKeys is the evaluation order of all properties which is computed once when the mapping is built.
+	orderedKeys []string
 }
 
 var _ FieldMappingInterface = (*FieldMappingObject)(nil)
@@ -141,32 +356,148 @@ func (FieldMappingObject) Type() FieldMappingType {
 
 // IsZero checks if the field mapping object is empty.
 func (fm FieldMappingObject) IsZero() bool {
-	return len(fm.Properties) == 0
+	return len(fm.Properties) == 0 && fm.Spread == nil
 }
 
 // Equal checks if this instance equals the target value.
 func (fm FieldMappingObject) Equal(target FieldMappingObject) bool {
-	return goutils.EqualMap(fm.Properties, target.Properties, false)
+	return fm.Ordered == target.Ordered &&
+		fm.OnError == target.OnError &&
+		slices.Equal(fm.Keys, target.Keys) &&
+		goutils.DeepEqual(fm.Spread, target.Spread, false) &&
+		goutils.EqualMap(fm.Properties, target.Properties, false)
 }
 
 // Evaluate validates and transforms data with the specified JMES path.
+// Properties are evaluated in the order of keys.
+// Missing required fields of all properties are collected into a single [MissingFieldsError].
+// Errors of properties are tolerated according to their error policies.
 func (fm FieldMappingObject) Evaluate(data any) (any, error) {
-	result := make(map[string]any)
+	return fm.evaluateScope(newEvaluationScope(data, nil))
+}
+
+func (fm FieldMappingObject) evaluateScope(scope *evaluationScope) (any, error) {
+	result := NewOrderedObject()
+
+	if fm.Spread != nil {
+		err := fm.Spread.apply(result, scope)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var missingFields []MissingFieldError
 
-	for key, field := range fm.Properties {
+	for _, key := range fm.propertyKeys() {
+		field := fm.Properties[key]
 		if field.FieldMappingInterface == nil {
 			return nil, nil
 		}
 
-		value, err := field.Evaluate(data)
+		value, err := evaluateFieldMapping(field, scope.withProperty(key))
 		if err != nil {
+			if errors.Is(err, errFieldOmitted) {
+				continue
+			}
+
+			var missingErr *MissingFieldsError
+
+			if errors.As(err, &missingErr) {
+				missingFields = append(missingFields, missingErr.withParent(key)...)
+
+				continue
+			}
+
 			return nil, fmt.Errorf("%s: %w", key, err)
 		}
 
-		result[key] = value
+		result.Set(key, value)
 	}
 
-	return result, nil
+	if len(missingFields) > 0 {
+		return nil, &MissingFieldsError{Fields: missingFields}
+	}
+
+	if fm.Ordered {
+		return result, nil
+	}
+
+	return result.ToMap(), nil
+}
+
+func (fm FieldMappingObject) errorPolicy() ErrorPolicy {
+	return fm.OnError
+}
+
+func (fm FieldMappingObject) nestedFieldMappings() []FieldMapping {
+	results := make([]FieldMapping, 0, len(fm.Properties))
+
+	for _, key := range fm.propertyKeys() {
+		results = append(results, fm.Properties[key])
+	}
+
+	return results
+}
+
+// propertyKeys returns property keys in the evaluation order.
+// The order is computed on the fly if the object is not built from a config.
+func (fm FieldMappingObject) propertyKeys() []string {
+	if len(fm.orderedKeys) == len(fm.Properties) {
+		return fm.orderedKeys
+	}
+
+	return orderPropertyKeys(fm.Keys, fm.Properties)
+}
+
+// FieldMappingSpread copies all keys of an object selected by a JMESPath expression.
+type FieldMappingSpread struct {
+	// Path is a JMESPath expression to select the object to be copied. The input data is used if empty.
+	Path string `json:"path,omitempty" yaml:"path,omitempty" jsonschema:"description=JMESPath expression to select the object to be copied. The input data is used if empty"`
+	// Exclude is the list of keys which are not copied.
+	Exclude []string `json:"exclude,omitempty" yaml:"exclude,omitempty" jsonschema:"description=List of keys which are not copied"`
+}
+
+// apply copies keys of the selected object to the result object in sorted order.
+func (fs FieldMappingSpread) apply(result *OrderedObject, scope *evaluationScope) error {
+	source := scope.current
+
+	if fs.Path != "" {
+		var err error
+
+		source, err = scope.search(fs.Path)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate spread path: %w", err)
+		}
+	}
+
+	if source == nil {
+		return nil
+	}
+
+	rv := reflect.ValueOf(source)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf(
+			"%w, expected an object to spread, got %s",
+			ErrFieldMappingObjectMalformed,
+			rv.Type(),
+		)
+	}
+
+	keys := make([]string, 0, rv.Len())
+
+	for _, key := range rv.MapKeys() {
+		if !slices.Contains(fs.Exclude, key.String()) {
+			keys = append(keys, key.String())
+		}
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		result.Set(key, rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key())).Interface())
+	}
+
+	return nil
 }
 
 // FieldMappingEntryString is the entry to lookup string values with the specified JMES path.
@@ -175,6 +506,8 @@ type FieldMappingEntryString struct {
 	Path *string
 	// Default value to be used when no value is found when looking up the value using the path.
 	Default *string
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy
 }
 
 var _ FieldMappingInterface = (*FieldMappingEntryString)(nil)
@@ -192,18 +525,45 @@ func (fm FieldMappingEntryString) IsZero() bool {
 // Equal checks if this instance equals the target value.
 func (fm FieldMappingEntryString) Equal(target FieldMappingEntryString) bool {
 	return goutils.EqualComparablePtr(fm.Path, target.Path) &&
-		goutils.EqualComparablePtr(fm.Default, target.Default)
+		goutils.EqualComparablePtr(fm.Default, target.Default) &&
+		fm.OnError == target.OnError
 }
 
 // Evaluate validates and transforms data with the specified JMES path, returning any value.
 func (fm FieldMappingEntryString) Evaluate(data any) (any, error) {
-	return fm.EvaluateString(data)
+	return fm.evaluateScope(newEvaluationScope(data, nil))
+}
+
+func (fm FieldMappingEntryString) errorPolicy() ErrorPolicy {
+	return fm.OnError
+}
+
+// defaultValue returns the default value, or null if it is not set.
+func (fm FieldMappingEntryString) defaultValue() any {
+	if fm.Default == nil {
+		return nil
+	}
+
+	return *fm.Default
+}
+
+func (fm FieldMappingEntryString) evaluateScope(scope *evaluationScope) (any, error) {
+	result, err := fm.evaluateString(scope)
+	if err != nil || result == nil {
+		return nil, err
+	}
+
+	return *result, nil
 }
 
 // EvaluateString validates and transforms data with the specified JMES path, returning string value explicitly.
 func (fm FieldMappingEntryString) EvaluateString(data any) (*string, error) {
+	return fm.evaluateString(newEvaluationScope(data, nil))
+}
+
+func (fm FieldMappingEntryString) evaluateString(scope *evaluationScope) (*string, error) {
 	if fm.Path != nil {
-		result, err := fm.evaluateStringFromPath(data)
+		result, err := fm.evaluateStringFromPath(scope)
 		if err != nil {
 			return nil, err
 		}
@@ -220,18 +580,18 @@ func (fm FieldMappingEntryString) EvaluateString(data any) (*string, error) {
 	return nil, nil
 }
 
-func (fm FieldMappingEntryString) evaluateStringFromPath(data any) (*string, error) {
+func (fm FieldMappingEntryString) evaluateStringFromPath(scope *evaluationScope) (*string, error) {
 	var result any
 
 	if *fm.Path != "" {
 		var err error
 
-		result, err = jmespath.Search(*fm.Path, data)
+		result, err = scope.search(*fm.Path)
 		if err != nil {
 			return nil, fmt.Errorf("failed to evaluate mapping entry string: %w", err)
 		}
 	} else {
-		result = data
+		result = scope.current
 	}
 
 	if result == nil {
@@ -3,6 +3,7 @@ package jmes
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/hasura/goenvconf"
 	"github.com/relychan/goutils"
@@ -61,6 +62,16 @@ func (fm FieldMappingConfig) Equal(target FieldMappingConfig) bool {
 		return goutils.DeepEqual(fmi, target.FieldMappingConfigInterface, true)
 	case *FieldMappingObjectConfig:
 		return goutils.DeepEqual(fmi, target.FieldMappingConfigInterface, true)
+	case *FieldMappingRefConfig:
+		return goutils.DeepEqual(fmi, target.FieldMappingConfigInterface, true)
+	case *FieldMappingDiscriminatorConfig:
+		return goutils.DeepEqual(fmi, target.FieldMappingConfigInterface, true)
+	case *FieldMappingDictionaryConfig:
+		return goutils.DeepEqual(fmi, target.FieldMappingConfigInterface, true)
+	case *FieldMappingEntriesConfig:
+		return goutils.DeepEqual(fmi, target.FieldMappingConfigInterface, true)
+	case *FieldMappingTemplateConfig:
+		return goutils.DeepEqual(fmi, target.FieldMappingConfigInterface, true)
 	default:
 		return false
 	}
@@ -75,15 +86,9 @@ func (fm *FieldMappingConfig) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	var config FieldMappingConfigInterface
-
-	switch temp.Type {
-	case FieldMappingTypeObject:
-		config = new(FieldMappingObjectConfig)
-	case FieldMappingTypeField:
-		config = new(FieldMappingEntryConfig)
-	default:
-		return fmt.Errorf("%w: %s", ErrUnsupportedFieldMappingType, temp.Type)
+	config, err := newFieldMappingConfigByType(temp.Type)
+	if err != nil {
+		return err
 	}
 
 	err = json.Unmarshal(b, config)
@@ -107,15 +112,9 @@ func (fm *FieldMappingConfig) UnmarshalYAML(value *yaml.Node) error {
 		return ErrFieldMappingTypeRequired
 	}
 
-	var config FieldMappingConfigInterface
-
-	switch FieldMappingType(*rawConfigType) {
-	case FieldMappingTypeObject:
-		config = new(FieldMappingObjectConfig)
-	case FieldMappingTypeField:
-		config = new(FieldMappingEntryConfig)
-	default:
-		return fmt.Errorf("%w: %s", ErrUnsupportedFieldMappingType, *rawConfigType)
+	config, err := newFieldMappingConfigByType(FieldMappingType(*rawConfigType))
+	if err != nil {
+		return err
 	}
 
 	err = value.Decode(config)
@@ -128,12 +127,51 @@ func (fm *FieldMappingConfig) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// newFieldMappingConfigByType creates an empty field mapping config of the mapping type.
+func newFieldMappingConfigByType(fieldType FieldMappingType) (FieldMappingConfigInterface, error) {
+	switch fieldType {
+	case FieldMappingTypeObject:
+		return new(FieldMappingObjectConfig), nil
+	case FieldMappingTypeRef:
+		return new(FieldMappingRefConfig), nil
+	case FieldMappingTypeDiscriminator:
+		return new(FieldMappingDiscriminatorConfig), nil
+	case FieldMappingTypeDictionary:
+		return new(FieldMappingDictionaryConfig), nil
+	case FieldMappingTypeEntries:
+		return new(FieldMappingEntriesConfig), nil
+	case FieldMappingTypeTemplate:
+		return new(FieldMappingTemplateConfig), nil
+	case FieldMappingTypeField:
+		return new(FieldMappingEntryConfig), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFieldMappingType, fieldType)
+	}
+}
+
 // FieldMappingEntryConfig is the entry config to lookup field values with the specified JMES path.
 type FieldMappingEntryConfig struct {
 	// Path is a JMESPath expression to find a value in the input data.
 	Path *string `json:"path,omitempty" yaml:"path,omitempty" jsonschema:"description=JMESPath expression to find a value in the input data"`
+	// Paths are fallback JMESPath expressions which are evaluated in order if the previous expression resolves to nothing.
+	// The first non-null result is used before falling back to the default value.
+	Paths []string `json:"paths,omitempty" yaml:"paths,omitempty" jsonschema:"description=Fallback JMESPath expressions which are evaluated in order if the previous expression resolves to nothing"`
 	// Default value to be used when no value is found when looking up the value using the path.
 	Default *goenvconf.EnvAny `json:"default,omitempty" yaml:"default,omitempty" jsonschema:"description=Default value to be used when no value is found"`
+	// NullPolicy is the condition to use the default value. Defaults to defaultOnNull.
+	NullPolicy NullPolicy `json:"nullPolicy,omitempty" yaml:"nullPolicy,omitempty" jsonschema:"enum=defaultOnNull,enum=defaultOnMissing,enum=defaultOnEmpty,default=defaultOnNull,description=Condition to use the default value. defaultOnMissing keeps explicit nulls and defaultOnEmpty also replaces empty strings and collections"`
+	// Required makes the evaluation fail instead of using the default value, e.g. if the path resolves to nothing.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty" jsonschema:"description=Fail the evaluation if the path resolves to nothing"`
+	// ErrorMessage is the custom error message when the required field is missing.
+	ErrorMessage string `json:"errorMessage,omitempty" yaml:"errorMessage,omitempty" jsonschema:"description=Custom error message when the required field is missing"`
+	// Cast converts the found value to the target type before falling back to the default value.
+	Cast *FieldCast `json:"cast,omitempty" yaml:"cast,omitempty" jsonschema:"description=Convert the found value to the target type before falling back to the default value"`
+	// ValueType is the expected type of the result. Any type is accepted if empty.
+	ValueType ValueType `json:"valueType,omitempty" yaml:"valueType,omitempty" jsonschema:"enum=string,enum=number,enum=integer,enum=boolean,enum=object,enum=array,description=Expected type of the result. Any type is accepted if empty"`
+	// Lookup translates the found value with an inline or named lookup table before casting.
+	Lookup *FieldLookup `json:"lookup,omitempty" yaml:"lookup,omitempty" jsonschema:"description=Translate the found value with an inline or named lookup table before casting"`
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy `json:"onError,omitempty" yaml:"onError,omitempty" jsonschema:"enum=fail,enum=useDefault,enum=null,enum=omit,default=fail,description=Behavior when the evaluation fails"`
 }
 
 var _ FieldMappingConfigInterface = (*FieldMappingEntryConfig)(nil)
@@ -145,13 +183,21 @@ func (FieldMappingEntryConfig) Type() FieldMappingType {
 
 // IsZero checks if the config is empty.
 func (fm FieldMappingEntryConfig) IsZero() bool {
-	return fm.Path == nil && fm.Default == nil
+	return fm.Path == nil && len(fm.Paths) == 0 && fm.Default == nil
 }
 
 // Equal checks if this instance equals the target value.
 func (fm FieldMappingEntryConfig) Equal(target FieldMappingEntryConfig) bool {
 	return goutils.EqualComparablePtr(fm.Path, target.Path) &&
-		goutils.DeepEqual(fm.Default, target.Default, false)
+		slices.Equal(fm.Paths, target.Paths) &&
+		goutils.DeepEqual(fm.Default, target.Default, false) &&
+		fm.NullPolicy == target.NullPolicy &&
+		fm.Required == target.Required &&
+		fm.ErrorMessage == target.ErrorMessage &&
+		goutils.DeepEqual(fm.Cast, target.Cast, false) &&
+		fm.ValueType == target.ValueType &&
+		goutils.DeepEqual(fm.Lookup, target.Lookup, false) &&
+		fm.OnError == target.OnError
 }
 
 // EvaluateEnv converts the config to the field mapping instance with environment variables.
@@ -182,8 +228,26 @@ func (fm FieldMappingEntryConfig) EvaluateEntry(
 		return FieldMappingEntry{}, ErrFieldMappingEntryRequired
 	}
 
+	err := fm.validate()
+	if err != nil {
+		return FieldMappingEntry{}, err
+	}
+
 	result := FieldMappingEntry{
-		Path: fm.Path,
+		Path:         fm.Path,
+		Paths:        fm.Paths,
+		NullPolicy:   fm.NullPolicy,
+		Required:     fm.Required,
+		ErrorMessage: fm.ErrorMessage,
+		Cast:         fm.Cast,
+		ValueType:    fm.ValueType,
+		OnError:      fm.OnError,
+	}
+
+	if fm.Lookup != nil {
+		// copy the lookup so named tables are bound to the field mapping instead of the config.
+		lookup := *fm.Lookup
+		result.Lookup = &lookup
 	}
 
 	if fm.Default != nil {
@@ -192,12 +256,65 @@ func (fm FieldMappingEntryConfig) EvaluateEntry(
 			return FieldMappingEntry{}, err
 		}
 
+		if fm.ValueType != "" {
+			err := fm.ValueType.Check(value)
+			if err != nil {
+				return FieldMappingEntry{}, fmt.Errorf("default: %w", err)
+			}
+		}
+
 		result.Default = value
 	}
 
 	return result, nil
 }
 
+func (fm FieldMappingEntryConfig) validate() error {
+	if fm.Required && (fm.Path == nil || *fm.Path == "") && len(fm.Paths) == 0 {
+		return fmt.Errorf(
+			"%w: path must not be empty if the field is required",
+			ErrFieldMappingEntryMalformed,
+		)
+	}
+
+	if fm.Cast != nil {
+		err := fm.Cast.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if fm.ValueType != "" {
+		err := fm.ValueType.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if fm.NullPolicy != "" {
+		err := fm.NullPolicy.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if fm.Lookup != nil {
+		err := fm.Lookup.Validate()
+		if err != nil {
+			return fmt.Errorf("lookup: %w", err)
+		}
+	}
+
+	if fm.OnError != "" {
+		err := fm.OnError.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // EvaluateEntryEnv converts the config to the field mapping entry instance.
 func (fm FieldMappingEntryConfig) EvaluateEntryEnv() (FieldMappingEntry, error) {
 	return fm.EvaluateEntry(goenvconf.GetOSEnv)
@@ -205,10 +322,23 @@ func (fm FieldMappingEntryConfig) EvaluateEntryEnv() (FieldMappingEntry, error)
 
 // FieldMappingObjectConfig represents configurations for the object field mapping.
 type FieldMappingObjectConfig struct {
+	// Spread copies all keys of the selected object into the result. Properties override the copied keys.
+	Spread *FieldMappingSpread `json:"spread,omitempty" yaml:"spread,omitempty"`
 	// Properties of the field mapping object.
-	Properties map[string]FieldMappingConfig `json:"properties" yaml:"properties"`
+	// Dotted keys such as author.names, or JSON pointers such as /author/names, create nested objects.
+	// Use \. to escape a literal dot in dotted keys.
+	Properties map[string]FieldMappingConfig `json:"properties,omitempty" yaml:"properties,omitempty"`
+	// Ordered returns an object which keeps the declaration order of properties when marshaling, instead of a map.
+	Ordered bool `json:"ordered,omitempty" yaml:"ordered,omitempty"`
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy `json:"onError,omitempty" yaml:"onError,omitempty" jsonschema:"enum=fail,enum=useDefault,enum=null,enum=omit,default=fail,description=Behavior when the evaluation fails"`
+
+	// propertyKeys is the declaration order of all properties which is computed once when the config is decoded.
+	propertyKeys []string
 }
 
+type rawFieldMappingObjectConfig FieldMappingObjectConfig
+
 var _ FieldMappingConfigInterface = (*FieldMappingObjectConfig)(nil)
 
 // Type returns the type of field mapping config.
@@ -218,12 +348,70 @@ func (FieldMappingObjectConfig) Type() FieldMappingType {
 
 // IsZero checks if the config is empty.
 func (fm FieldMappingObjectConfig) IsZero() bool {
-	return fm.Properties == nil
+	return fm.Properties == nil && fm.Spread == nil
 }
 
 // Equal checks if this instance equals the target value.
 func (fm FieldMappingObjectConfig) Equal(target FieldMappingObjectConfig) bool {
-	return goutils.EqualMap(fm.Properties, target.Properties, true)
+	return fm.Ordered == target.Ordered &&
+		fm.OnError == target.OnError &&
+		goutils.DeepEqual(fm.Spread, target.Spread, false) &&
+		goutils.EqualMap(fm.Properties, target.Properties, true)
+}
+
+// PropertyKeys returns property keys in the declaration order.
+// Keys of configs which are not decoded from JSON or YAML are sorted.
+func (fm FieldMappingObjectConfig) PropertyKeys() []string {
+	if len(fm.propertyKeys) == len(fm.Properties) {
+		return slices.Clone(fm.propertyKeys)
+	}
+
+	return orderPropertyKeys(fm.propertyKeys, fm.Properties)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (fm *FieldMappingObjectConfig) UnmarshalJSON(b []byte) error {
+	var raw rawFieldMappingObjectConfig
+
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	var rawProperties struct {
+		Properties json.RawMessage `json:"properties"`
+	}
+
+	err = json.Unmarshal(b, &rawProperties)
+	if err != nil {
+		return err
+	}
+
+	keys, err := getJSONObjectKeys(rawProperties.Properties)
+	if err != nil {
+		return err
+	}
+
+	raw.propertyKeys = orderPropertyKeys(keys, raw.Properties)
+
+	*fm = FieldMappingObjectConfig(raw)
+
+	return nil
+}
+
+// UnmarshalYAML implements the custom behavior for the yaml.Unmarshaler interface.
+func (fm *FieldMappingObjectConfig) UnmarshalYAML(value *yaml.Node) error {
+	var raw rawFieldMappingObjectConfig
+
+	err := value.Decode(&raw)
+	if err != nil {
+		return err
+	}
+
+	raw.propertyKeys = orderPropertyKeys(getYAMLObjectKeys(value, "properties"), raw.Properties)
+	*fm = FieldMappingObjectConfig(raw)
+
+	return nil
 }
 
 // EvaluateEnv converts the config to the field mapping instance with environment variables.
@@ -237,23 +425,41 @@ func (fm FieldMappingObjectConfig) Evaluate(getEnvFunc goenvconf.GetEnvFunc) (Fi
 		return FieldMapping{}, ErrFieldMappingObjectRequired
 	}
 
-	result := FieldMappingObject{
-		Properties: make(map[string]FieldMapping),
+	if fm.OnError != "" {
+		err := fm.OnError.Validate()
+		if err != nil {
+			return FieldMapping{}, err
+		}
 	}
 
-	for key, fieldConfig := range fm.Properties {
+	root := newPropertyNode()
+
+	for _, key := range fm.PropertyKeys() {
+		fieldConfig := fm.Properties[key]
 		if fieldConfig.FieldMappingConfigInterface == nil {
 			return FieldMapping{}, fmt.Errorf("%s: %w", key, ErrFieldMappingEntryRequired)
 		}
 
+		segments, err := parsePropertyKey(key)
+		if err != nil {
+			return FieldMapping{}, err
+		}
+
 		field, err := fieldConfig.Evaluate(getEnvFunc)
 		if err != nil {
 			return FieldMapping{}, fmt.Errorf("%s: %w", key, err)
 		}
 
-		result.Properties[key] = field
+		err = root.Set(key, segments, field)
+		if err != nil {
+			return FieldMapping{}, err
+		}
 	}
 
+	result := root.Build(fm.Ordered)
+	result.Spread = fm.Spread
+	result.OnError = fm.OnError
+
 	return NewFieldMapping(result), nil
 }
 
@@ -263,6 +469,8 @@ type FieldMappingEntryStringConfig struct {
 	Path *string `json:"path,omitempty" yaml:"path,omitempty"`
 	// Default value to be used when no value is found when looking up the value using the path.
 	Default *goenvconf.EnvString `json:"default,omitempty" yaml:"default,omitempty"`
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy `json:"onError,omitempty" yaml:"onError,omitempty" jsonschema:"enum=fail,enum=useDefault,enum=null,enum=omit,default=fail,description=Behavior when the evaluation fails"`
 }
 
 var _ FieldMappingConfigInterface = (*FieldMappingEntryStringConfig)(nil)
@@ -280,7 +488,8 @@ func (fm FieldMappingEntryStringConfig) IsZero() bool {
 // Equal checks if this instance equals the target value.
 func (fm FieldMappingEntryStringConfig) Equal(target FieldMappingEntryStringConfig) bool {
 	return goutils.EqualComparablePtr(fm.Path, target.Path) &&
-		goutils.EqualPtr(fm.Default, target.Default)
+		goutils.EqualPtr(fm.Default, target.Default) &&
+		fm.OnError == target.OnError
 }
 
 // EvaluateEnv converts the config to the field mapping instance with environment variables.
@@ -308,8 +517,16 @@ func (fm FieldMappingEntryStringConfig) EvaluateString(
 		return FieldMappingEntryString{}, ErrFieldMappingEntryRequired
 	}
 
+	if fm.OnError != "" {
+		err := fm.OnError.Validate()
+		if err != nil {
+			return FieldMappingEntryString{}, err
+		}
+	}
+
 	result := FieldMappingEntryString{
-		Path: fm.Path,
+		Path:    fm.Path,
+		OnError: fm.OnError,
 	}
 
 	if fm.Default != nil {
@@ -2,6 +2,8 @@ package jmes
 
 import (
 	"encoding/json"
+	"errors"
+	"slices"
 	"testing"
 
 	"github.com/hasura/goenvconf"
@@ -77,6 +79,19 @@ func TestFieldMappingEntryConfig_EvaluateEntry(t *testing.T) {
 		}
 	})
 
+	t.Run("evaluate with fallback paths", func(t *testing.T) {
+		config := FieldMappingEntryConfig{Paths: []string{"name", "fullName"}, Required: true}
+
+		entry, err := config.EvaluateEntryEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if !slices.Equal(entry.Paths, config.Paths) {
+			t.Errorf("expected paths to be %v, got: %v", config.Paths, entry.Paths)
+		}
+	})
+
 	t.Run("evaluate with default", func(t *testing.T) {
 		path := "name"
 		defaultVal := goenvconf.NewEnvAny("", "default")
@@ -92,6 +107,61 @@ func TestFieldMappingEntryConfig_EvaluateEntry(t *testing.T) {
 		}
 	})
 
+	t.Run("evaluate with required", func(t *testing.T) {
+		path := "name"
+		config := FieldMappingEntryConfig{Path: &path, Required: true, ErrorMessage: "name is required"}
+
+		entry, err := config.EvaluateEntryEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if !entry.Required || entry.ErrorMessage != "name is required" {
+			t.Errorf("expected required entry with custom message, got: %v", entry)
+		}
+	})
+
+	t.Run("error with default of mismatched value type", func(t *testing.T) {
+		path := "count"
+		defaultVal := goenvconf.NewEnvAny("", "zero")
+		config := FieldMappingEntryConfig{Path: &path, Default: &defaultVal, ValueType: ValueTypeInteger}
+
+		_, err := config.EvaluateEntryEnv()
+		if !errors.Is(err, ErrFieldMappingEntryMalformed) {
+			t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("error with invalid value type", func(t *testing.T) {
+		path := "count"
+		config := FieldMappingEntryConfig{Path: &path, ValueType: "date"}
+
+		_, err := config.EvaluateEntryEnv()
+		if !errors.Is(err, ErrFieldMappingEntryMalformed) {
+			t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("error with invalid cast", func(t *testing.T) {
+		path := "name"
+		config := FieldMappingEntryConfig{Path: &path, Cast: &FieldCast{Type: "unknown"}}
+
+		_, err := config.EvaluateEntryEnv()
+		if !errors.Is(err, ErrFieldMappingEntryMalformed) {
+			t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("error with required and no path", func(t *testing.T) {
+		defaultVal := goenvconf.NewEnvAny("", "default")
+		config := FieldMappingEntryConfig{Default: &defaultVal, Required: true}
+
+		_, err := config.EvaluateEntryEnv()
+		if !errors.Is(err, ErrFieldMappingEntryMalformed) {
+			t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+		}
+	})
+
 	t.Run("error with empty config", func(t *testing.T) {
 		config := FieldMappingEntryConfig{}
 
@@ -201,6 +271,35 @@ func TestFieldMappingObjectConfig_Evaluate(t *testing.T) {
 		}
 	})
 
+	t.Run("evaluate spread object", func(t *testing.T) {
+		yamlData := `
+type: object
+spread:
+  path: user
+  exclude: [password]
+`
+
+		var config FieldMappingConfig
+		err := yaml.Unmarshal([]byte(yamlData), &config)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		mapping, err := config.EvaluateEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		obj, ok := mapping.FieldMappingInterface.(FieldMappingObject)
+		if !ok {
+			t.Fatalf("expected mapping to be FieldMappingObject, got: %T", mapping.FieldMappingInterface)
+		}
+
+		if obj.Spread == nil || obj.Spread.Path != "user" || len(obj.Spread.Exclude) != 1 {
+			t.Errorf("expected spread of user, got: %v", obj.Spread)
+		}
+	})
+
 	t.Run("error with nil config", func(t *testing.T) {
 		config := FieldMappingObjectConfig{}
 
@@ -228,6 +327,97 @@ func TestFieldMappingObjectConfig_Evaluate(t *testing.T) {
 	})
 }
 
+func TestFieldMappingObjectConfig_PropertyKeys(t *testing.T) {
+	expected := []string{"zeta", "alpha", "author.name", "beta"}
+
+	t.Run("json", func(t *testing.T) {
+		jsonData := `{"type": "object", "ordered": true, "properties": {
+			"zeta": {"type": "field", "path": "z"},
+			"alpha": {"type": "field", "path": "a"},
+			"author.name": {"type": "field", "path": "n"},
+			"beta": {"type": "object", "ordered": true, "properties": {"y": {"type": "field", "path": "y"}, "x": {"type": "field", "path": "x"}}}
+		}}`
+
+		var config FieldMappingConfig
+		err := json.Unmarshal([]byte(jsonData), &config)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		objConfig, ok := config.FieldMappingConfigInterface.(*FieldMappingObjectConfig)
+		if !ok {
+			t.Fatalf("expected config to be FieldMappingObjectConfig, got: %T", config.FieldMappingConfigInterface)
+		}
+
+		if !slices.Equal(objConfig.PropertyKeys(), expected) {
+			t.Errorf("expected keys %v, got: %v", expected, objConfig.PropertyKeys())
+		}
+
+		mapping, err := config.EvaluateEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		result, err := mapping.Evaluate(map[string]any{"z": 1, "a": 2, "n": "Anna", "x": 3, "y": 4})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		resultBytes, err := json.Marshal(result)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expectedJSON := `{"zeta":1,"alpha":2,"author":{"name":"Anna"},"beta":{"y":4,"x":3}}`
+		if string(resultBytes) != expectedJSON {
+			t.Errorf("expected %s, got: %s", expectedJSON, resultBytes)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		yamlData := `
+type: object
+properties:
+  zeta:
+    type: field
+    path: z
+  alpha:
+    type: field
+    path: a
+  author.name:
+    type: field
+    path: n
+  beta:
+    type: field
+    path: b
+`
+
+		var config FieldMappingObjectConfig
+		err := yaml.Unmarshal([]byte(yamlData), &config)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if !slices.Equal(config.PropertyKeys(), expected) {
+			t.Errorf("expected keys %v, got: %v", expected, config.PropertyKeys())
+		}
+	})
+
+	t.Run("sorted keys without declaration order", func(t *testing.T) {
+		path := "name"
+		config := FieldMappingObjectConfig{
+			Properties: map[string]FieldMappingConfig{
+				"b": NewFieldMappingConfig(&FieldMappingEntryConfig{Path: &path}),
+				"a": NewFieldMappingConfig(&FieldMappingEntryConfig{Path: &path}),
+			},
+		}
+
+		if !slices.Equal(config.PropertyKeys(), []string{"a", "b"}) {
+			t.Errorf("expected sorted keys, got: %v", config.PropertyKeys())
+		}
+	})
+}
+
 func TestFieldMappingEntryStringConfig_Type(t *testing.T) {
 	config := FieldMappingEntryStringConfig{}
 	if config.Type() != FieldMappingTypeField {
@@ -334,6 +524,62 @@ func TestFieldMappingConfig_UnmarshalJSON(t *testing.T) {
 		}
 	})
 
+	t.Run("unmarshal typed field", func(t *testing.T) {
+		jsonData := `{"type": "field", "path": "age", "valueType": "integer"}`
+
+		var config FieldMappingConfig
+		err := json.Unmarshal([]byte(jsonData), &config)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		entry, ok := config.FieldMappingConfigInterface.(*FieldMappingEntryConfig)
+		if !ok {
+			t.Fatalf("expected config to be FieldMappingEntryConfig, got: %T", config.FieldMappingConfigInterface)
+		}
+
+		if entry.ValueType != ValueTypeInteger {
+			t.Errorf("expected value type to be integer, got: %s", entry.ValueType)
+		}
+	})
+
+	t.Run("unmarshal string field", func(t *testing.T) {
+		jsonData := `{"type": "field", "path": "name", "valueType": "string", "default": {"value": "unknown"}}`
+
+		var config FieldMappingConfig
+		err := json.Unmarshal([]byte(jsonData), &config)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		entry, ok := config.FieldMappingConfigInterface.(*FieldMappingEntryConfig)
+		if !ok {
+			t.Fatalf("expected config to be FieldMappingEntryConfig, got: %T", config.FieldMappingConfigInterface)
+		}
+
+		if entry.Path == nil || *entry.Path != "name" {
+			t.Errorf("expected path to be 'name', got: %v", entry.Path)
+		}
+
+		if entry.ValueType != ValueTypeString {
+			t.Errorf("expected value type to be string, got: %s", entry.ValueType)
+		}
+	})
+
+	t.Run("error with required string field missing", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(
+			t,
+			`{"type": "field", "valueType": "string", "path": "missing", "required": true}`,
+		)
+
+		_, err := mapping.Evaluate(map[string]any{"name": "John"})
+
+		var missingErr *MissingFieldsError
+		if !errors.As(err, &missingErr) {
+			t.Fatalf("expected MissingFieldsError, got: %v", err)
+		}
+	})
+
 	t.Run("error with unsupported type", func(t *testing.T) {
 		jsonData := `{"type": "unsupported"}`
 
@@ -363,6 +609,49 @@ path: name
 		}
 	})
 
+	t.Run("unmarshal string field", func(t *testing.T) {
+		yamlData := `
+type: field
+path: name
+valueType: string
+`
+
+		var config FieldMappingConfig
+		err := yaml.Unmarshal([]byte(yamlData), &config)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if _, ok := config.FieldMappingConfigInterface.(*FieldMappingEntryConfig); !ok {
+			t.Fatalf("expected config to be FieldMappingEntryConfig, got: %T", config.FieldMappingConfigInterface)
+		}
+	})
+
+	t.Run("unmarshal field type with cast", func(t *testing.T) {
+		yamlData := `
+type: field
+path: active
+cast:
+  type: boolean
+  mode: lenient
+`
+
+		var config FieldMappingConfig
+		err := yaml.Unmarshal([]byte(yamlData), &config)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		entry, ok := config.FieldMappingConfigInterface.(*FieldMappingEntryConfig)
+		if !ok {
+			t.Fatalf("expected config to be FieldMappingEntryConfig, got: %T", config.FieldMappingConfigInterface)
+		}
+
+		if entry.Cast == nil || entry.Cast.Type != CastTypeBoolean || entry.Cast.Mode != CastModeLenient {
+			t.Errorf("expected boolean lenient cast, got: %v", entry.Cast)
+		}
+	})
+
 	t.Run("unmarshal object type", func(t *testing.T) {
 		yamlData := `
 type: object
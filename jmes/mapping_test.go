@@ -1,6 +1,9 @@
 package jmes
 
 import (
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/relychan/goutils"
@@ -175,6 +178,163 @@ func TestFieldMappingEntry_Evaluate(t *testing.T) {
 		}
 	})
 
+	t.Run("evaluate with cast", func(t *testing.T) {
+		path := "age"
+		entry := FieldMappingEntry{Path: &path, Cast: &FieldCast{Type: CastTypeInteger}}
+		data := map[string]any{"age": "30"}
+
+		result, err := entry.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if result != int64(30) {
+			t.Errorf("expected result to be 30, got: %v", result)
+		}
+	})
+
+	t.Run("evaluate with lenient cast and default", func(t *testing.T) {
+		path := "age"
+		entry := FieldMappingEntry{
+			Path:    &path,
+			Default: int64(0),
+			Cast:    &FieldCast{Type: CastTypeInteger, Mode: CastModeLenient},
+		}
+		data := map[string]any{"age": "unknown"}
+
+		result, err := entry.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if result != int64(0) {
+			t.Errorf("expected result to be the default value, got: %v", result)
+		}
+	})
+
+	t.Run("error with strict cast", func(t *testing.T) {
+		path := "age"
+		entry := FieldMappingEntry{Path: &path, Cast: &FieldCast{Type: CastTypeInteger}}
+		data := map[string]any{"age": "unknown"}
+
+		_, err := entry.Evaluate(data)
+		if !errors.Is(err, ErrFieldMappingEntryMalformed) {
+			t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("evaluate with value type", func(t *testing.T) {
+		path := "tags"
+		entry := FieldMappingEntry{Path: &path, ValueType: ValueTypeArray}
+		data := map[string]any{"tags": []any{"a", "b"}}
+
+		result, err := entry.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if !goutils.DeepEqual(result, []any{"a", "b"}, false) {
+			t.Errorf("expected result to be the tags, got: %v", result)
+		}
+	})
+
+	t.Run("error with mismatched value type", func(t *testing.T) {
+		path := "tags"
+		entry := FieldMappingEntry{Path: &path, ValueType: ValueTypeObject}
+		data := map[string]any{"tags": []any{"a", "b"}}
+
+		_, err := entry.Evaluate(data)
+		if !errors.Is(err, ErrFieldMappingEntryMalformed) {
+			t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("error with required field missing", func(t *testing.T) {
+		path := "nonexistent"
+		entry := FieldMappingEntry{Path: &path, Default: "default_value", Required: true}
+		data := map[string]any{"name": "John"}
+
+		_, err := entry.Evaluate(data)
+
+		var missingErr *MissingFieldsError
+		if !errors.As(err, &missingErr) {
+			t.Fatalf("expected MissingFieldsError, got: %v", err)
+		}
+
+		if len(missingErr.Fields) != 1 || missingErr.Fields[0].Path != path {
+			t.Errorf("expected the missing field path to be %s, got: %v", path, missingErr.Fields)
+		}
+	})
+
+	t.Run("evaluate required field", func(t *testing.T) {
+		path := "name"
+		entry := FieldMappingEntry{Path: &path, Required: true}
+		data := map[string]any{"name": "John"}
+
+		result, err := entry.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if result != "John" {
+			t.Errorf("expected result to be 'John', got: %v", result)
+		}
+	})
+
+	t.Run("evaluate first non-null path", func(t *testing.T) {
+		path := "v2.name"
+		entry := FieldMappingEntry{Path: &path, Paths: []string{"v1.fullName", "name"}}
+
+		testCases := []struct {
+			Data     map[string]any
+			Expected any
+		}{
+			{Data: map[string]any{"v2": map[string]any{"name": "v2"}, "name": "root"}, Expected: "v2"},
+			{Data: map[string]any{"v1": map[string]any{"fullName": "v1"}, "name": "root"}, Expected: "v1"},
+			{Data: map[string]any{"name": "root"}, Expected: "root"},
+		}
+
+		for _, tc := range testCases {
+			result, err := entry.Evaluate(tc.Data)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if result != tc.Expected {
+				t.Errorf("expected result to be %v, got: %v", tc.Expected, result)
+			}
+		}
+	})
+
+	t.Run("evaluate paths with default", func(t *testing.T) {
+		entry := FieldMappingEntry{Paths: []string{"a", "b"}, Default: "default_value"}
+
+		result, err := entry.Evaluate(map[string]any{"c": "value"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if result != "default_value" {
+			t.Errorf("expected result to be 'default_value', got: %v", result)
+		}
+	})
+
+	t.Run("error with all required paths missing", func(t *testing.T) {
+		path := "a"
+		entry := FieldMappingEntry{Path: &path, Paths: []string{"b"}, Required: true}
+
+		_, err := entry.Evaluate(map[string]any{})
+
+		var missingErr *MissingFieldsError
+		if !errors.As(err, &missingErr) {
+			t.Fatalf("expected MissingFieldsError, got: %v", err)
+		}
+
+		if missingErr.Fields[0].Path != "a, b" {
+			t.Errorf("expected the missing field path to be 'a, b', got: %s", missingErr.Fields[0].Path)
+		}
+	})
+
 	t.Run("error with invalid path", func(t *testing.T) {
 		path := "invalid[["
 		entry := FieldMappingEntry{Path: &path}
@@ -322,6 +482,160 @@ func TestFieldMappingObject_Evaluate(t *testing.T) {
 		}
 	})
 
+	t.Run("evaluate with spread", func(t *testing.T) {
+		firstNamePath := "user.first_name"
+		obj := FieldMappingObject{
+			Spread: &FieldMappingSpread{Path: "user", Exclude: []string{"password", "first_name"}},
+			Properties: map[string]FieldMapping{
+				"firstName": NewFieldMapping(&FieldMappingEntry{Path: &firstNamePath}),
+				"email":     NewFieldMapping(&FieldMappingEntry{Default: "hidden"}),
+			},
+		}
+		data := map[string]any{
+			"user": map[string]any{
+				"first_name": "Jane",
+				"email":      "jane@example.com",
+				"password":   "secret",
+				"age":        30,
+			},
+		}
+
+		result, err := obj.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string]any{
+			"firstName": "Jane",
+			"email":     "hidden",
+			"age":       30,
+		}
+		if !goutils.DeepEqual(result, expected, false) {
+			t.Errorf("expected result to be %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("evaluate with spread of typed map", func(t *testing.T) {
+		obj := FieldMappingObject{
+			Spread: &FieldMappingSpread{},
+		}
+		data := map[string]string{"name": "John"}
+
+		result, err := obj.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if !goutils.DeepEqual(result, map[string]any{"name": "John"}, false) {
+			t.Errorf("expected result to be copied, got: %v", result)
+		}
+	})
+
+	t.Run("error with spread of non-object", func(t *testing.T) {
+		obj := FieldMappingObject{
+			Spread: &FieldMappingSpread{Path: "tags"},
+		}
+		data := map[string]any{"tags": []any{"a"}}
+
+		_, err := obj.Evaluate(data)
+		if !errors.Is(err, ErrFieldMappingObjectMalformed) {
+			t.Errorf("expected error to be ErrFieldMappingObjectMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("error with all missing required fields", func(t *testing.T) {
+		idPath := "id"
+		namePath := "user.name"
+		emailPath := "user.email"
+		obj := FieldMappingObject{
+			Properties: map[string]FieldMapping{
+				"id": NewFieldMapping(&FieldMappingEntry{Path: &idPath, Required: true}),
+				"user": NewFieldMapping(&FieldMappingObject{
+					Properties: map[string]FieldMapping{
+						"name": NewFieldMapping(&FieldMappingEntry{
+							Path:         &namePath,
+							Required:     true,
+							ErrorMessage: "user name is required",
+						}),
+						"email": NewFieldMapping(&FieldMappingEntry{Path: &emailPath}),
+					},
+				}),
+			},
+		}
+
+		_, err := obj.Evaluate(map[string]any{})
+
+		var missingErr *MissingFieldsError
+		if !errors.As(err, &missingErr) {
+			t.Fatalf("expected MissingFieldsError, got: %v", err)
+		}
+
+		if len(missingErr.Fields) != 2 {
+			t.Fatalf("expected 2 missing fields, got: %v", missingErr.Fields)
+		}
+
+		properties := map[string]MissingFieldError{}
+		for _, field := range missingErr.Fields {
+			properties[field.Property] = field
+		}
+
+		if properties["id"].Path != idPath {
+			t.Errorf("expected missing field id, got: %v", missingErr.Fields)
+		}
+
+		if properties["user.name"].Message != "user name is required" {
+			t.Errorf("expected missing field user.name, got: %v", missingErr.Fields)
+		}
+	})
+
+	t.Run("evaluate ordered object", func(t *testing.T) {
+		namePath := "name"
+		agePath := "age"
+		obj := FieldMappingObject{
+			Keys:    []string{"userName", "userAge"},
+			Ordered: true,
+			Spread:  &FieldMappingSpread{Exclude: []string{"name", "age"}},
+			Properties: map[string]FieldMapping{
+				"userAge":  NewFieldMapping(&FieldMappingEntry{Path: &agePath}),
+				"userName": NewFieldMapping(&FieldMappingEntry{Path: &namePath}),
+			},
+		}
+		data := map[string]any{"name": "John", "age": 30, "b": 2, "a": 1}
+
+		result, err := obj.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		resultBytes, err := json.Marshal(result)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := `{"a":1,"b":2,"userName":"John","userAge":30}`
+		if string(resultBytes) != expected {
+			t.Errorf("expected %s, got: %s", expected, resultBytes)
+		}
+	})
+
+	t.Run("error of the first property in order", func(t *testing.T) {
+		invalidPath := "invalid[["
+		obj := FieldMappingObject{
+			Properties: map[string]FieldMapping{
+				"b": NewFieldMapping(&FieldMappingEntry{Path: &invalidPath}),
+				"a": NewFieldMapping(&FieldMappingEntry{Path: &invalidPath}),
+				"c": NewFieldMapping(&FieldMappingEntry{Path: &invalidPath}),
+			},
+		}
+
+		for range 10 {
+			_, err := obj.Evaluate(map[string]any{})
+			if err == nil || !strings.HasPrefix(err.Error(), "a: ") {
+				t.Fatalf("expected error of property a, got: %v", err)
+			}
+		}
+	})
+
 	t.Run("error with nil field mapping", func(t *testing.T) {
 		obj := FieldMappingObject{
 			Properties: map[string]FieldMapping{
@@ -427,6 +741,29 @@ func TestFieldMappingEntryString_EvaluateString(t *testing.T) {
 		}
 	})
 
+	t.Run("evaluate returns plain string", func(t *testing.T) {
+		path := "name"
+		entry := FieldMappingEntryString{Path: &path}
+
+		result, err := entry.Evaluate(map[string]any{"name": "John"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if result != "John" {
+			t.Errorf("expected result to be 'John', got: %v", result)
+		}
+
+		result, err = entry.Evaluate(map[string]any{})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if result != nil {
+			t.Errorf("expected result to be nil, got: %v", result)
+		}
+	})
+
 	t.Run("error with non-string value", func(t *testing.T) {
 		path := "age"
 		entry := FieldMappingEntryString{Path: &path}
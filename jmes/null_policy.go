@@ -0,0 +1,65 @@
+package jmes
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// NullPolicy represents the enum of conditions to use the default value of a field mapping.
+type NullPolicy string
+
+const (
+	// NullPolicyDefaultOnNull uses the default value if the path is missing or the value is null.
+	NullPolicyDefaultOnNull NullPolicy = "defaultOnNull"
+	// NullPolicyDefaultOnMissing uses the default value only if the path is missing, so explicit nulls are kept.
+	NullPolicyDefaultOnMissing NullPolicy = "defaultOnMissing"
+	// NullPolicyDefaultOnEmpty uses the default value if the value is null, an empty string, array or object.
+	NullPolicyDefaultOnEmpty NullPolicy = "defaultOnEmpty"
+)
+
+var enumValuesNullPolicy = []NullPolicy{
+	NullPolicyDefaultOnNull,
+	NullPolicyDefaultOnMissing,
+	NullPolicyDefaultOnEmpty,
+}
+
+var errUnsupportedNullPolicy = errors.New("unsupported null policy")
+
+// Validate checks if the null policy is valid.
+func (np NullPolicy) Validate() error {
+	if !slices.Contains(enumValuesNullPolicy, np) {
+		return fmt.Errorf("%w: %w: %s", ErrFieldMappingEntryMalformed, errUnsupportedNullPolicy, np)
+	}
+
+	return nil
+}
+
+// useDefault checks if the default value should be used for the search result.
+// The present flag tells if the path exists in the input data, even if the value is null.
+func (np NullPolicy) useDefault(value any, present bool) bool {
+	switch np {
+	case NullPolicyDefaultOnMissing:
+		return value == nil && !present
+	case NullPolicyDefaultOnEmpty:
+		return value == nil || isEmptyValue(value)
+	default:
+		return value == nil
+	}
+}
+
+func isEmptyValue(value any) bool {
+	if oo, ok := value.(*OrderedObject); ok {
+		return oo.Len() == 0
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,103 @@
+package jmes
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNullPolicy_Validate(t *testing.T) {
+	for _, np := range enumValuesNullPolicy {
+		if err := np.Validate(); err != nil {
+			t.Errorf("expected no error for %s, got: %v", np, err)
+		}
+	}
+
+	if err := NullPolicy("never").Validate(); !errors.Is(err, ErrFieldMappingEntryMalformed) {
+		t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+	}
+}
+
+func TestFieldMappingEntry_EvaluateNullPolicy(t *testing.T) {
+	data := map[string]any{
+		"deleted": nil,
+		"name":    "",
+		"tags":    []any{},
+		"items":   []any{nil},
+		"user":    map[string]any{"nickname": nil},
+	}
+
+	testCases := []struct {
+		Name       string
+		Path       string
+		NullPolicy NullPolicy
+		Expected   any
+	}{
+		{Name: "null on null", Path: "deleted", Expected: "default"},
+		{Name: "null on missing", Path: "unknown", Expected: "default"},
+		{Name: "missing keeps explicit null", Path: "deleted", NullPolicy: NullPolicyDefaultOnMissing, Expected: nil},
+		{Name: "missing keeps nested null", Path: "user.nickname", NullPolicy: NullPolicyDefaultOnMissing, Expected: nil},
+		{Name: "missing keeps null item", Path: "items[-1]", NullPolicy: NullPolicyDefaultOnMissing, Expected: nil},
+		{Name: "missing key", Path: "unknown", NullPolicy: NullPolicyDefaultOnMissing, Expected: "default"},
+		{Name: "missing nested key", Path: "user.name", NullPolicy: NullPolicyDefaultOnMissing, Expected: "default"},
+		{Name: "missing item", Path: "items[1]", NullPolicy: NullPolicyDefaultOnMissing, Expected: "default"},
+		{Name: "missing keeps empty string", Path: "name", NullPolicy: NullPolicyDefaultOnMissing, Expected: ""},
+		{Name: "empty string", Path: "name", NullPolicy: NullPolicyDefaultOnEmpty, Expected: "default"},
+		{Name: "empty array", Path: "tags", NullPolicy: NullPolicyDefaultOnEmpty, Expected: "default"},
+		{Name: "empty null", Path: "deleted", NullPolicy: NullPolicyDefaultOnEmpty, Expected: "default"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			entry := FieldMappingEntry{Path: &tc.Path, Default: "default", NullPolicy: tc.NullPolicy}
+
+			result, err := entry.Evaluate(data)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if !reflect.DeepEqual(tc.Expected, result) {
+				t.Errorf("expected %v, got: %v", tc.Expected, result)
+			}
+		})
+	}
+
+	t.Run("required accepts explicit null", func(t *testing.T) {
+		path := "deleted"
+		entry := FieldMappingEntry{Path: &path, Required: true, NullPolicy: NullPolicyDefaultOnMissing}
+
+		result, err := entry.Evaluate(data)
+		if err != nil || result != nil {
+			t.Fatalf("expected null without error, got: %v, %v", result, err)
+		}
+	})
+
+	t.Run("required rejects empty value", func(t *testing.T) {
+		path := "name"
+		entry := FieldMappingEntry{Path: &path, Required: true, NullPolicy: NullPolicyDefaultOnEmpty}
+
+		_, err := entry.Evaluate(data)
+		if !errors.Is(err, ErrRequiredFieldMissing) {
+			t.Fatalf("expected ErrRequiredFieldMissing, got: %v", err)
+		}
+	})
+
+	t.Run("coalesce prefers non-null values over explicit null", func(t *testing.T) {
+		path := "deleted"
+		entry := FieldMappingEntry{
+			Path:       &path,
+			Paths:      []string{"unknown", "user"},
+			Default:    "default",
+			NullPolicy: NullPolicyDefaultOnMissing,
+		}
+
+		result, err := entry.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if !reflect.DeepEqual(data["user"], result) {
+			t.Errorf("expected %v, got: %v", data["user"], result)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package jmes
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// OrderedObject is an object result which keeps keys in the order of the mapping config.
+// It is returned by object mappings with the ordered option enabled.
+type OrderedObject struct {
+	keys   []string
+	values map[string]any
+}
+
+// NewOrderedObject creates an empty OrderedObject instance.
+func NewOrderedObject() *OrderedObject {
+	return &OrderedObject{
+		values: map[string]any{},
+	}
+}
+
+// Len returns the number of keys.
+func (oo *OrderedObject) Len() int {
+	return len(oo.keys)
+}
+
+// Keys returns keys in insertion order.
+func (oo *OrderedObject) Keys() []string {
+	return oo.keys
+}
+
+// Get returns the value of the key.
+func (oo *OrderedObject) Get(key string) (any, bool) {
+	value, ok := oo.values[key]
+
+	return value, ok
+}
+
+// Set sets the value of the key. Existing keys keep their position.
+func (oo *OrderedObject) Set(key string, value any) {
+	if _, ok := oo.values[key]; !ok {
+		oo.keys = append(oo.keys, key)
+	}
+
+	oo.values[key] = value
+}
+
+// ToMap converts the ordered object to a plain map. Nested ordered objects are kept as is.
+func (oo *OrderedObject) ToMap() map[string]any {
+	result := make(map[string]any, len(oo.values))
+
+	for key, value := range oo.values {
+		result[key] = value
+	}
+
+	return result
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (oo *OrderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, key := range oo.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		keyBytes, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		valueBytes, err := json.Marshal(oo.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(keyBytes)
+		buf.WriteByte(':')
+		buf.Write(valueBytes)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (oo *OrderedObject) MarshalYAML() (any, error) {
+	node := &yaml.Node{
+		Kind:    yaml.MappingNode,
+		Content: make([]*yaml.Node, 0, len(oo.keys)*2),
+	}
+
+	for _, key := range oo.keys {
+		var keyNode, valueNode yaml.Node
+
+		err := keyNode.Encode(key)
+		if err != nil {
+			return nil, err
+		}
+
+		err = valueNode.Encode(oo.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		node.Content = append(node.Content, &keyNode, &valueNode)
+	}
+
+	return node, nil
+}
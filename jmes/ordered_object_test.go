@@ -0,0 +1,57 @@
+package jmes
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"go.yaml.in/yaml/v4"
+)
+
+func TestOrderedObject(t *testing.T) {
+	obj := NewOrderedObject()
+	obj.Set("zeta", 1)
+	obj.Set("alpha", "a")
+	obj.Set("zeta", 2)
+
+	nested := NewOrderedObject()
+	nested.Set("b", true)
+	nested.Set("a", nil)
+	obj.Set("nested", nested)
+
+	if obj.Len() != 3 {
+		t.Errorf("expected 3 keys, got: %d", obj.Len())
+	}
+
+	if !slices.Equal(obj.Keys(), []string{"zeta", "alpha", "nested"}) {
+		t.Errorf("expected keys in insertion order, got: %v", obj.Keys())
+	}
+
+	if value, ok := obj.Get("zeta"); !ok || value != 2 {
+		t.Errorf("expected zeta to be 2, got: %v", value)
+	}
+
+	t.Run("marshal json", func(t *testing.T) {
+		result, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := `{"zeta":2,"alpha":"a","nested":{"b":true,"a":null}}`
+		if string(result) != expected {
+			t.Errorf("expected %s, got: %s", expected, result)
+		}
+	})
+
+	t.Run("marshal yaml", func(t *testing.T) {
+		result, err := yaml.Marshal(obj)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := "zeta: 2\nalpha: a\nnested:\n    b: true\n    a: null\n"
+		if string(result) != expected {
+			t.Errorf("expected %q, got: %q", expected, result)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package jmes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parsePropertyKey splits a property key of an object mapping into the path of nested output keys.
+// Keys starting with a slash are JSON pointers (RFC 6901), e.g. /author/names, where ~1 and ~0 escape / and ~.
+// Otherwise, keys are split by dots, e.g. author.names, where \. and \\ escape a literal dot and backslash.
+func parsePropertyKey(key string) ([]string, error) {
+	var segments []string
+
+	if strings.HasPrefix(key, "/") {
+		segments = strings.Split(key[1:], "/")
+
+		for i, segment := range segments {
+			segments[i] = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+		}
+	} else {
+		segments = splitDottedKey(key)
+	}
+
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf(
+				"%w, property key %q must not have empty segments",
+				ErrFieldMappingObjectMalformed,
+				key,
+			)
+		}
+	}
+
+	return segments, nil
+}
+
+func splitDottedKey(key string) []string {
+	if !strings.ContainsAny(key, `.\`) {
+		return []string{key}
+	}
+
+	var (
+		segments []string
+		sb       strings.Builder
+	)
+
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+
+		switch {
+		case c == '\\' && i+1 < len(key) && (key[i+1] == '.' || key[i+1] == '\\'):
+			i++
+			sb.WriteByte(key[i])
+		case c == '.':
+			segments = append(segments, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return append(segments, sb.String())
+}
+
+// propertyNode places field mappings at nested paths of an object mapping in declaration order,
+// creating the intermediate object mappings on demand.
+type propertyNode struct {
+	keys     []string
+	fields   map[string]FieldMapping
+	children map[string]*propertyNode
+}
+
+func newPropertyNode() *propertyNode {
+	return &propertyNode{
+		fields:   map[string]FieldMapping{},
+		children: map[string]*propertyNode{},
+	}
+}
+
+// Set puts the field mapping at the path. It returns an error if the path conflicts with another property.
+func (pn *propertyNode) Set(key string, segments []string, field FieldMapping) error {
+	segment := segments[0]
+
+	if _, ok := pn.fields[segment]; ok {
+		return fmt.Errorf("%w: %s", ErrFieldMappingPropertyConflict, key)
+	}
+
+	child, hasChild := pn.children[segment]
+
+	if len(segments) == 1 {
+		if hasChild {
+			return fmt.Errorf("%w: %s", ErrFieldMappingPropertyConflict, key)
+		}
+
+		pn.keys = append(pn.keys, segment)
+		pn.fields[segment] = field
+
+		return nil
+	}
+
+	if !hasChild {
+		child = newPropertyNode()
+		pn.keys = append(pn.keys, segment)
+		pn.children[segment] = child
+	}
+
+	return child.Set(key, segments[1:], field)
+}
+
+// Build creates the object mapping. Intermediate objects inherit the ordered option.
+func (pn *propertyNode) Build(ordered bool) FieldMappingObject {
+	result := FieldMappingObject{
+		Keys:        pn.keys,
+		orderedKeys: pn.keys,
+		Ordered:     ordered,
+		Properties:  make(map[string]FieldMapping, len(pn.keys)),
+	}
+
+	for key, field := range pn.fields {
+		result.Properties[key] = field
+	}
+
+	for key, child := range pn.children {
+		result.Properties[key] = NewFieldMapping(child.Build(ordered))
+	}
+
+	return result
+}
@@ -0,0 +1,109 @@
+package jmes
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/relychan/goutils"
+)
+
+func TestParsePropertyKey(t *testing.T) {
+	testCases := []struct {
+		Key      string
+		Expected []string
+	}{
+		{Key: "name", Expected: []string{"name"}},
+		{Key: "author.names", Expected: []string{"author", "names"}},
+		{Key: `file\.name`, Expected: []string{"file.name"}},
+		{Key: `a\\.b`, Expected: []string{`a\`, "b"}},
+		{Key: `a\b`, Expected: []string{`a\b`}},
+		{Key: "/author/names", Expected: []string{"author", "names"}},
+		{Key: "/a.b/c~1d/e~0f", Expected: []string{"a.b", "c/d", "e~f"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Key, func(t *testing.T) {
+			result, err := parsePropertyKey(tc.Key)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if !slices.Equal(result, tc.Expected) {
+				t.Errorf("expected %v, got: %v", tc.Expected, result)
+			}
+		})
+	}
+
+	for _, key := range []string{"a..b", ".a", "a.", "/", "/a//b"} {
+		t.Run(key, func(t *testing.T) {
+			_, err := parsePropertyKey(key)
+			if !errors.Is(err, ErrFieldMappingObjectMalformed) {
+				t.Errorf("expected error to be ErrFieldMappingObjectMalformed, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestFieldMappingObjectConfig_NestedProperties(t *testing.T) {
+	namesPath := "authors[*].name"
+	countPath := "length(authors)"
+	titlePath := "title"
+
+	t.Run("build nested objects", func(t *testing.T) {
+		config := FieldMappingObjectConfig{
+			Properties: map[string]FieldMappingConfig{
+				"author.names":  NewFieldMappingConfig(&FieldMappingEntryConfig{Path: &namesPath}),
+				"/author/count": NewFieldMappingConfig(&FieldMappingEntryConfig{Path: &countPath}),
+				`book\.title`:   NewFieldMappingConfig(&FieldMappingEntryConfig{Path: &titlePath}),
+			},
+		}
+
+		mapping, err := config.EvaluateEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		result, err := mapping.Evaluate(map[string]any{
+			"title":   "Go",
+			"authors": []any{map[string]any{"name": "Anna"}, map[string]any{"name": "Tom"}},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string]any{
+			"book.title": "Go",
+			"author": map[string]any{
+				"names": []any{"Anna", "Tom"},
+				"count": float64(2),
+			},
+		}
+		if !goutils.DeepEqual(result, expected, false) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	conflictCases := map[string][]string{
+		"same path":          {"author.names", "/author/names"},
+		"leaf and parent":    {"author", "author.names"},
+		"deeper than a leaf": {"author.names", "author.names.first"},
+	}
+
+	for name, keys := range conflictCases {
+		t.Run(name, func(t *testing.T) {
+			config := FieldMappingObjectConfig{
+				Properties: map[string]FieldMappingConfig{},
+			}
+
+			for _, key := range keys {
+				config.Properties[key] = NewFieldMappingConfig(&FieldMappingEntryConfig{Path: &namesPath})
+			}
+
+			_, err := config.EvaluateEnv()
+			if !errors.Is(err, ErrFieldMappingPropertyConflict) {
+				t.Errorf("expected error to be ErrFieldMappingPropertyConflict, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package jmes
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hasura/goenvconf"
+	"github.com/relychan/goutils"
+)
+
+var (
+	// ErrFieldMappingRefRequired occurs when the name of a reference mapping is empty.
+	ErrFieldMappingRefRequired = errors.New("field mapping reference must not be empty")
+	// ErrFieldMappingRefUndefined occurs when a reference mapping points at an unknown definition.
+	ErrFieldMappingRefUndefined = errors.New("field mapping definition is not defined")
+	// ErrFieldMappingRefUnresolved occurs when a reference mapping is evaluated before it is resolved.
+	ErrFieldMappingRefUnresolved = errors.New("field mapping reference is not resolved")
+	// ErrFieldMappingRefCycle occurs when definitions reference each other without narrowing the input data,
+	// so the evaluation would never end.
+	ErrFieldMappingRefCycle = errors.New("field mapping definitions contain a reference cycle")
+)
+
+// FieldMappingRef evaluates a named definition of the transformer config.
+type FieldMappingRef struct {
+	// Name of the referenced definition.
+	Name string
+	// Path is a JMESPath expression to select the input data of the definition.
+	// The current input data is used if nil.
+	Path *string
+	// Items applies the definition to each item of the selected array.
+	Items bool
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy
+
+	definition *FieldMapping
+}
+
+var _ FieldMappingInterface = (*FieldMappingRef)(nil)
+
+// Type returns type of the field mapping reference.
+func (FieldMappingRef) Type() FieldMappingType {
+	return FieldMappingTypeRef
+}
+
+// IsZero checks if the field mapping reference is empty.
+func (fm FieldMappingRef) IsZero() bool {
+	return fm.Name == ""
+}
+
+// Equal checks if this instance equals the target value.
+// The resolved definition is not compared because definitions may be recursive.
+func (fm FieldMappingRef) Equal(target FieldMappingRef) bool {
+	return fm.Name == target.Name &&
+		fm.Items == target.Items &&
+		fm.OnError == target.OnError &&
+		goutils.EqualComparablePtr(fm.Path, target.Path)
+}
+
+// Evaluate validates and transforms data with the referenced definition.
+// The result is null if the definition is omitted by its error policy.
+func (fm FieldMappingRef) Evaluate(data any) (any, error) {
+	result, err := fm.evaluateScope(newEvaluationScope(data, nil))
+	if errors.Is(err, errFieldOmitted) {
+		return nil, nil
+	}
+
+	return result, err
+}
+
+func (fm FieldMappingRef) evaluateScope(scope *evaluationScope) (any, error) {
+	if fm.definition == nil || fm.definition.FieldMappingInterface == nil {
+		return nil, fmt.Errorf("%w: %s", ErrFieldMappingRefUnresolved, fm.Name)
+	}
+
+	scope, err := scope.withRef(fm.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	input := scope.current
+
+	if fm.Path != nil && *fm.Path != "" {
+		input, err = scope.search(*fm.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate reference path: %w", err)
+		}
+	}
+
+	if input == nil {
+		return nil, nil
+	}
+
+	if !fm.Items {
+		return evaluateFieldMapping(*fm.definition, scope.withCurrent(input))
+	}
+
+	rv := reflect.ValueOf(input)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf(
+			"%w, expected an array of items, got %s",
+			ErrFieldMappingEntryMalformed,
+			rv.Type(),
+		)
+	}
+
+	results := make([]any, 0, rv.Len())
+
+	var missingFields []MissingFieldError
+
+	for i := range rv.Len() {
+		itemScope := scope.withCurrent(rv.Index(i).Interface()).withProperty(strconv.Itoa(i))
+
+		value, err := evaluateFieldMapping(*fm.definition, itemScope)
+		if err != nil {
+			if errors.Is(err, errFieldOmitted) {
+				continue
+			}
+
+			var missingErr *MissingFieldsError
+
+			if errors.As(err, &missingErr) {
+				missingFields = append(missingFields, missingErr.withParent(strconv.Itoa(i))...)
+
+				continue
+			}
+
+			return nil, fmt.Errorf("%d: %w", i, err)
+		}
+
+		results = append(results, value)
+	}
+
+	if len(missingFields) > 0 {
+		return nil, &MissingFieldsError{Fields: missingFields}
+	}
+
+	return results, nil
+}
+
+func (fm FieldMappingRef) errorPolicy() ErrorPolicy {
+	return fm.OnError
+}
+
+// isScoped checks if the reference narrows the input data, so a recursive reference can end.
+// Paths which start from variables, e.g. $root or $current, may select the same data again so they are not scoped.
+func (fm FieldMappingRef) isScoped() bool {
+	if fm.Path == nil {
+		return false
+	}
+
+	path := strings.TrimSpace(*fm.Path)
+
+	return path != "" && path != "@" && !strings.HasPrefix(path, "$")
+}
+
+// FieldMappingRefConfig represents configurations for a reference to a named definition of the transformer config.
+type FieldMappingRefConfig struct {
+	// Ref is the name of the referenced definition.
+	Ref string `json:"ref" yaml:"ref" jsonschema:"description=Name of the referenced definition"`
+	// Path is a JMESPath expression to select the input data of the definition.
+	Path *string `json:"path,omitempty" yaml:"path,omitempty" jsonschema:"description=JMESPath expression to select the input data of the definition. The current input data is used if empty"`
+	// Items applies the definition to each item of the selected array.
+	Items bool `json:"items,omitempty" yaml:"items,omitempty" jsonschema:"description=Apply the definition to each item of the selected array"`
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy `json:"onError,omitempty" yaml:"onError,omitempty" jsonschema:"enum=fail,enum=useDefault,enum=null,enum=omit,default=fail,description=Behavior when the evaluation fails"`
+}
+
+var _ FieldMappingConfigInterface = (*FieldMappingRefConfig)(nil)
+
+// Type returns the type of field mapping config.
+func (FieldMappingRefConfig) Type() FieldMappingType {
+	return FieldMappingTypeRef
+}
+
+// IsZero checks if the config is empty.
+func (fm FieldMappingRefConfig) IsZero() bool {
+	return fm.Ref == ""
+}
+
+// Equal checks if this instance equals the target value.
+func (fm FieldMappingRefConfig) Equal(target FieldMappingRefConfig) bool {
+	return fm.Ref == target.Ref &&
+		fm.Items == target.Items &&
+		fm.OnError == target.OnError &&
+		goutils.EqualComparablePtr(fm.Path, target.Path)
+}
+
+// EvaluateEnv converts the config to the field mapping instance with environment variables.
+func (fm FieldMappingRefConfig) EvaluateEnv() (FieldMapping, error) {
+	return fm.Evaluate(goenvconf.GetOSEnv)
+}
+
+// Evaluate converts the config to the field mapping instance.
+// The reference is unresolved until definitions are bound by [JMESTransformerConfig.Evaluate].
+func (fm FieldMappingRefConfig) Evaluate(_ goenvconf.GetEnvFunc) (FieldMapping, error) {
+	if fm.IsZero() {
+		return FieldMapping{}, ErrFieldMappingRefRequired
+	}
+
+	if fm.OnError != "" {
+		err := fm.OnError.Validate()
+		if err != nil {
+			return FieldMapping{}, err
+		}
+	}
+
+	return NewFieldMapping(&FieldMappingRef{
+		Name:    fm.Ref,
+		Path:    fm.Path,
+		Items:   fm.Items,
+		OnError: fm.OnError,
+	}), nil
+}
+
+// EvaluateDefinitions converts definition configs to field mappings and resolves references of the template and definitions.
+// It returns an error if a reference is undefined or definitions reference each other without narrowing the input data.
+func EvaluateDefinitions(
+	template FieldMapping,
+	definitionConfigs map[string]FieldMappingConfig,
+	getEnvFunc goenvconf.GetEnvFunc,
+) error {
+	definitions := make(map[string]*FieldMapping, len(definitionConfigs))
+
+	for name, config := range definitionConfigs {
+		if config.FieldMappingConfigInterface == nil {
+			return fmt.Errorf("definitions.%s: %w", name, ErrFieldMappingEntryRequired)
+		}
+
+		definition, err := config.Evaluate(getEnvFunc)
+		if err != nil {
+			return fmt.Errorf("definitions.%s: %w", name, err)
+		}
+
+		definitions[name] = &definition
+	}
+
+	// edges of references which do not narrow the input data between definitions.
+	unscopedRefs := make(map[string][]string, len(definitions))
+
+	for name, definition := range definitions {
+		err := bindFieldMappingRefs(*definition, definitions, func(ref *FieldMappingRef) {
+			if !ref.isScoped() {
+				unscopedRefs[name] = append(unscopedRefs[name], ref.Name)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("definitions.%s: %w", name, err)
+		}
+	}
+
+	err := detectRefCycle(unscopedRefs)
+	if err != nil {
+		return err
+	}
+
+	return bindFieldMappingRefs(template, definitions, nil)
+}
+
+// bindFieldMappingRefs walks through the field mapping tree without following references,
+// and binds references to definitions.
+func bindFieldMappingRefs(
+	mapping FieldMapping,
+	definitions map[string]*FieldMapping,
+	onRef func(ref *FieldMappingRef),
+) error {
+	switch fm := mapping.FieldMappingInterface.(type) {
+	case *FieldMappingRef:
+		definition, ok := definitions[fm.Name]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrFieldMappingRefUndefined, fm.Name)
+		}
+
+		fm.definition = definition
+
+		if onRef != nil {
+			onRef(fm)
+		}
+	case fieldMappingContainer:
+		for _, child := range fm.nestedFieldMappings() {
+			err := bindFieldMappingRefs(child, definitions, onRef)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// detectRefCycle returns an error if the graph of unscoped references contains a cycle.
+func detectRefCycle(refs map[string][]string) error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	states := make(map[string]int, len(refs))
+
+	var visit func(name string, stack []string) error
+
+	visit = func(name string, stack []string) error {
+		switch states[name] {
+		case visiting:
+			start := slices.Index(stack, name)
+
+			return fmt.Errorf(
+				"%w: %s",
+				ErrFieldMappingRefCycle,
+				strings.Join(append(stack[start:], name), " -> "),
+			)
+		case visited:
+			return nil
+		}
+
+		states[name] = visiting
+
+		for _, next := range refs[name] {
+			err := visit(next, append(stack, name))
+			if err != nil {
+				return err
+			}
+		}
+
+		states[name] = visited
+
+		return nil
+	}
+
+	names := make([]string, 0, len(refs))
+
+	for name := range refs {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		err := visit(name, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package jmes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+	"github.com/relychan/goutils"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestFieldMappingRef_Evaluate(t *testing.T) {
+	t.Run("error with unresolved reference", func(t *testing.T) {
+		ref := FieldMappingRef{Name: "address"}
+
+		_, err := ref.Evaluate(map[string]any{})
+		if !errors.Is(err, ErrFieldMappingRefUnresolved) {
+			t.Errorf("expected error to be ErrFieldMappingRefUnresolved, got: %v", err)
+		}
+	})
+
+	t.Run("error with items of non-array", func(t *testing.T) {
+		path := "name"
+		definition := NewFieldMapping(&FieldMappingEntry{Path: &path})
+		ref := FieldMappingRef{Name: "name", Items: true, definition: &definition}
+
+		_, err := ref.Evaluate(map[string]any{"name": "foo"})
+		if !errors.Is(err, ErrFieldMappingEntryMalformed) {
+			t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("error with missing fields of items", func(t *testing.T) {
+		path := "name"
+		itemsPath := "items"
+		definition := NewFieldMapping(&FieldMappingObject{
+			Properties: map[string]FieldMapping{
+				"name": NewFieldMapping(&FieldMappingEntry{Path: &path, Required: true}),
+			},
+		})
+		ref := FieldMappingRef{Name: "item", Path: &itemsPath, Items: true, definition: &definition}
+
+		_, err := ref.Evaluate(map[string]any{
+			"items": []any{
+				map[string]any{},
+				map[string]any{"name": "foo"},
+				map[string]any{},
+			},
+		})
+
+		var missingErr *MissingFieldsError
+		if !errors.As(err, &missingErr) {
+			t.Fatalf("expected MissingFieldsError, got: %v", err)
+		}
+
+		properties := make([]string, len(missingErr.Fields))
+
+		for i, field := range missingErr.Fields {
+			properties[i] = field.Property
+		}
+
+		expected := []string{"0.name", "2.name"}
+		if !goutils.DeepEqual(properties, expected, false) {
+			t.Errorf("expected missing properties %v, got: %v", expected, properties)
+		}
+	})
+}
+
+func TestJMESTransformerConfig_Definitions(t *testing.T) {
+	t.Run("recursive definition", func(t *testing.T) {
+		yamlData := `
+template:
+  type: object
+  properties:
+    billing:
+      type: ref
+      ref: address
+      path: billing
+    tree:
+      type: ref
+      ref: node
+      path: root
+definitions:
+  address:
+    type: object
+    properties:
+      city:
+        type: field
+        path: city
+  node:
+    type: object
+    properties:
+      label:
+        type: field
+        path: name
+      children:
+        type: ref
+        ref: node
+        path: children
+        items: true
+`
+
+		var config JMESTransformerConfig
+		err := yaml.Unmarshal([]byte(yamlData), &config)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		mapping, err := config.Evaluate(goenvconf.GetOSEnv)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		result, err := mapping.Evaluate(map[string]any{
+			"billing": map[string]any{"city": "Hanoi"},
+			"root": map[string]any{
+				"name": "a",
+				"children": []any{
+					map[string]any{"name": "b"},
+					map[string]any{"name": "c", "children": []any{map[string]any{"name": "d"}}},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string]any{
+			"billing": map[string]any{"city": "Hanoi"},
+			"tree": map[string]any{
+				"label": "a",
+				"children": []any{
+					map[string]any{"label": "b", "children": nil},
+					map[string]any{
+						"label": "c",
+						"children": []any{
+							map[string]any{"label": "d", "children": nil},
+						},
+					},
+				},
+			},
+		}
+		if !goutils.DeepEqual(result, expected, false) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("error with undefined reference", func(t *testing.T) {
+		config := JMESTransformerConfig{
+			Template: NewFieldMappingConfig(&FieldMappingRefConfig{Ref: "unknown"}),
+		}
+
+		_, err := config.Evaluate(goenvconf.GetOSEnv)
+		if !errors.Is(err, ErrFieldMappingRefUndefined) {
+			t.Errorf("expected error to be ErrFieldMappingRefUndefined, got: %v", err)
+		}
+	})
+
+	t.Run("error with empty reference", func(t *testing.T) {
+		config := JMESTransformerConfig{
+			Template: NewFieldMappingConfig(&FieldMappingRefConfig{}),
+		}
+
+		_, err := config.Evaluate(goenvconf.GetOSEnv)
+		if !errors.Is(err, ErrFieldMappingRefRequired) {
+			t.Errorf("expected error to be ErrFieldMappingRefRequired, got: %v", err)
+		}
+	})
+
+	t.Run("error with reference cycle", func(t *testing.T) {
+		config := JMESTransformerConfig{
+			Template: NewFieldMappingConfig(&FieldMappingRefConfig{Ref: "a"}),
+			Definitions: map[string]FieldMappingConfig{
+				"a": NewFieldMappingConfig(&FieldMappingObjectConfig{
+					Properties: map[string]FieldMappingConfig{
+						"b": NewFieldMappingConfig(&FieldMappingRefConfig{Ref: "b"}),
+					},
+				}),
+				"b": NewFieldMappingConfig(&FieldMappingRefConfig{Ref: "a"}),
+			},
+		}
+
+		_, err := config.Evaluate(goenvconf.GetOSEnv)
+		if !errors.Is(err, ErrFieldMappingRefCycle) {
+			t.Fatalf("expected error to be ErrFieldMappingRefCycle, got: %v", err)
+		}
+
+		expected := "field mapping definitions contain a reference cycle: a -> b -> a"
+		if err.Error() != expected {
+			t.Errorf("expected error %q, got: %q", expected, err.Error())
+		}
+	})
+
+	t.Run("error with self reference", func(t *testing.T) {
+		path := "@"
+		config := JMESTransformerConfig{
+			Template: NewFieldMappingConfig(&FieldMappingRefConfig{Ref: "a"}),
+			Definitions: map[string]FieldMappingConfig{
+				"a": NewFieldMappingConfig(&FieldMappingRefConfig{Ref: "a", Path: &path}),
+			},
+		}
+
+		_, err := config.Evaluate(goenvconf.GetOSEnv)
+		if !errors.Is(err, ErrFieldMappingRefCycle) {
+			t.Errorf("expected error to be ErrFieldMappingRefCycle, got: %v", err)
+		}
+	})
+
+	t.Run("error with self reference of variables", func(t *testing.T) {
+		for _, path := range []string{"$", "$root", "$current", "$root.children"} {
+			config := JMESTransformerConfig{
+				Template: NewFieldMappingConfig(&FieldMappingRefConfig{Ref: "a"}),
+				Definitions: map[string]FieldMappingConfig{
+					"a": NewFieldMappingConfig(&FieldMappingRefConfig{Ref: "a", Path: &path}),
+				},
+			}
+
+			_, err := config.Evaluate(goenvconf.GetOSEnv)
+			if !errors.Is(err, ErrFieldMappingRefCycle) {
+				t.Errorf("%s: expected error to be ErrFieldMappingRefCycle, got: %v", path, err)
+			}
+		}
+	})
+
+	t.Run("error with recursion which does not narrow the data", func(t *testing.T) {
+		// the path looks scoped but selects the current data again.
+		path := "[@][0]"
+		config := JMESTransformerConfig{
+			Template: NewFieldMappingConfig(&FieldMappingRefConfig{Ref: "a"}),
+			Definitions: map[string]FieldMappingConfig{
+				"a": NewFieldMappingConfig(&FieldMappingObjectConfig{
+					Properties: map[string]FieldMappingConfig{
+						"child": NewFieldMappingConfig(&FieldMappingRefConfig{Ref: "a", Path: &path}),
+					},
+				}),
+			},
+		}
+
+		mapping, err := config.Evaluate(goenvconf.GetOSEnv)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		_, err = mapping.Evaluate(map[string]any{"name": "foo"})
+		if !errors.Is(err, ErrFieldMappingRefCycle) {
+			t.Errorf("expected error to be ErrFieldMappingRefCycle, got: %v", err)
+		}
+	})
+}
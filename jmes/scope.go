@@ -0,0 +1,178 @@
+package jmes
+
+import (
+	"fmt"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/jmespath-community/go-jmespath/pkg/interpreter"
+	"github.com/jmespath-community/go-jmespath/pkg/parsing"
+)
+
+// Names of the variables which are bound to JMESPath expressions of field mappings.
+const (
+	// VariableRoot is the root document of the transformation. It is also addressable with $.
+	VariableRoot = "$root"
+	// VariableCurrent is the input data of the current field mapping. It is also addressable with @ outside projections.
+	VariableCurrent = "$current"
+	// VariableVars is the object of variables of the transformer config.
+	VariableVars = "$vars"
+)
+
+// maxRefDepth is the maximum number of nested references of an evaluation.
+const maxRefDepth = 256
+
+// evaluationScope holds the data which are addressable from JMESPath expressions of nested field mappings.
+type evaluationScope struct {
+	root      any
+	current   any
+	variables map[string]any
+	// property is the dotted path of the current field in the output.
+	property string
+	// warnings collects errors which are tolerated by error policies. Warnings are discarded if nil.
+	warnings *[]FieldWarning
+	// refDepth is the number of references which are being evaluated.
+	refDepth int
+}
+
+func newEvaluationScope(data any, variables map[string]any) *evaluationScope {
+	if variables == nil {
+		variables = map[string]any{}
+	}
+
+	return &evaluationScope{
+		root:      data,
+		current:   data,
+		variables: variables,
+	}
+}
+
+// withCurrent returns a copy of the scope with another input data, e.g. the selected item of a reference.
+func (es *evaluationScope) withCurrent(current any) *evaluationScope {
+	result := *es
+	result.current = current
+
+	return &result
+}
+
+// withProperty returns a copy of the scope for the nested field of the output.
+func (es *evaluationScope) withProperty(key string) *evaluationScope {
+	result := *es
+
+	if es.property == "" {
+		result.property = key
+	} else {
+		result.property = es.property + "." + key
+	}
+
+	return &result
+}
+
+// withRef returns a copy of the scope for the definition of a reference.
+// It returns an error if references are nested too deeply, e.g. the path of a recursive reference does not narrow the data.
+func (es *evaluationScope) withRef(name string) (*evaluationScope, error) {
+	if es.refDepth >= maxRefDepth {
+		return nil, fmt.Errorf("%w: %s exceeds the maximum depth of %d", ErrFieldMappingRefCycle, name, maxRefDepth)
+	}
+
+	result := *es
+	result.refDepth++
+
+	return &result, nil
+}
+
+// warn records the warning of the evaluation with the error policy which tolerates the error, if any.
+func (es *evaluationScope) warn(policy ErrorPolicy, err error) {
+	if es.warnings == nil {
+		return
+	}
+
+	*es.warnings = append(*es.warnings, FieldWarning{
+		Property: es.property,
+		Policy:   policy,
+		Err:      err,
+	})
+}
+
+// search evaluates the JMESPath expression against the current data with the root document and variables bound.
+func (es *evaluationScope) search(expression string) (any, error) {
+	node, err := parsing.NewParser().Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return es.execute(node, es.current)
+}
+
+// exists checks if the field or index which the JMESPath expression selects is present in the data, even if its value is null.
+// Only fields, indexes and sub-expressions of them can be detected. Other expressions are treated as missing if the result is null.
+func (es *evaluationScope) exists(expression string) (bool, error) {
+	node, err := parsing.NewParser().Parse(expression)
+	if err != nil {
+		return false, err
+	}
+
+	return es.existsNode(node, es.current)
+}
+
+func (es *evaluationScope) existsNode(node parsing.ASTNode, value any) (bool, error) {
+	switch node.NodeType {
+	case parsing.ASTField:
+		key, ok := node.Value.(string)
+
+		return ok && hasObjectKey(value, key), nil
+	case parsing.ASTSubexpression, parsing.ASTIndexExpression:
+		if len(node.Children) != 2 {
+			return false, nil
+		}
+
+		parent, err := es.execute(node.Children[0], value)
+		if err != nil || parent == nil {
+			return false, err
+		}
+
+		if node.NodeType == parsing.ASTSubexpression {
+			return es.existsNode(node.Children[1], parent)
+		}
+
+		index, ok := node.Children[1].Value.(int)
+
+		return ok && node.Children[1].NodeType == parsing.ASTIndex && hasArrayIndex(parent, index), nil
+	default:
+		return false, nil
+	}
+}
+
+func (es *evaluationScope) execute(node parsing.ASTNode, value any) (any, error) {
+	bindings := binding.NewBindings().
+		Register(VariableRoot, binding.NewBinding(es.root)).
+		Register(VariableCurrent, binding.NewBinding(es.current)).
+		Register(VariableVars, binding.NewBinding(es.variables))
+
+	return interpreter.NewInterpreter(es.root, bindings).Execute(node, value)
+}
+
+// scopedFieldMapping is implemented by field mappings which pass the root document and variables to nested mappings.
+type scopedFieldMapping interface {
+	evaluateScope(scope *evaluationScope) (any, error)
+}
+
+// evaluateFieldMapping evaluates the field mapping within the scope and applies its error policy.
+// Custom field mappings only receive the current data.
+func evaluateFieldMapping(fm FieldMapping, scope *evaluationScope) (any, error) {
+	var (
+		result any
+		err    error
+	)
+
+	if scoped, ok := fm.FieldMappingInterface.(scopedFieldMapping); ok {
+		result, err = scoped.evaluateScope(scope)
+	} else {
+		result, err = fm.Evaluate(scope.current)
+	}
+
+	if err != nil {
+		return recoverFieldMapping(fm, scope, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package jmes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvaluationScope_Search(t *testing.T) {
+	root := map[string]any{
+		"id":    1,
+		"items": []any{map[string]any{"name": "pen"}},
+	}
+	scope := newEvaluationScope(root, map[string]any{"locale": "en"})
+	itemScope := scope.withCurrent(map[string]any{"name": "pen"})
+
+	testCases := []struct {
+		Name       string
+		Expression string
+		Expected   any
+	}{
+		{Name: "current element", Expression: "name", Expected: "pen"},
+		{Name: "current variable", Expression: "$current.name", Expected: "pen"},
+		{Name: "root variable", Expression: "$root.id", Expected: 1},
+		{Name: "root node", Expression: "$.id", Expected: 1},
+		{Name: "variables", Expression: "$vars.locale", Expected: "en"},
+		{Name: "undefined variable key", Expression: "$vars.unknown", Expected: nil},
+		{
+			Name:       "root in projection",
+			Expression: "$root.items[*].[name, $root.id]",
+			Expected:   []any{[]any{"pen", 1}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := itemScope.search(tc.Expression)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if !reflect.DeepEqual(tc.Expected, result) {
+				t.Errorf("expected %v, got: %v", tc.Expected, result)
+			}
+		})
+	}
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := scope.search("[")
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestEvaluateFieldMapping_Scope(t *testing.T) {
+	namePath := "name"
+	idPath := "$root.id"
+	localePath := "$vars.locale"
+	itemsPath := "items"
+
+	definition := NewFieldMapping(FieldMappingObject{
+		Properties: map[string]FieldMapping{
+			"name":   NewFieldMapping(FieldMappingEntry{Path: &namePath}),
+			"id":     NewFieldMapping(FieldMappingEntry{Path: &idPath}),
+			"locale": NewFieldMapping(FieldMappingEntryString{Path: &localePath}),
+		},
+	})
+	mapping := NewFieldMapping(&FieldMappingRef{
+		Name:       "item",
+		Path:       &itemsPath,
+		Items:      true,
+		definition: &definition,
+	})
+
+	data := map[string]any{
+		"id":    1,
+		"items": []any{map[string]any{"name": "pen"}},
+	}
+
+	transformer := NewJMESTemplateTransformer(mapping, WithVariables(map[string]any{"locale": "en"}))
+
+	result, err := transformer.Transform(data)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := []any{map[string]any{"name": "pen", "id": 1, "locale": "en"}}
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("expected %v, got: %v", expected, result)
+	}
+
+	t.Run("without transformer", func(t *testing.T) {
+		result, err := mapping.Evaluate(data)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := []any{map[string]any{"name": "pen", "id": 1, "locale": nil}}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+}
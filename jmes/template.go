@@ -0,0 +1,221 @@
+package jmes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/hasura/goenvconf"
+	"github.com/relychan/gotransform/gotmpl"
+	"github.com/relychan/goutils"
+)
+
+// ErrFieldMappingTemplateMalformed occurs when the template of a field mapping can not be parsed.
+var ErrFieldMappingTemplateMalformed = errors.New("field mapping template is malformed")
+
+// FieldMappingTemplate renders a string from the current input data with a Go template.
+type FieldMappingTemplate struct {
+	// Source is the text of the Go template.
+	Source string
+	// Functions is the policy of functions which are available to the template. All functions are available if nil.
+	Functions *gotmpl.GoTemplateFunctions
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy
+
+	template *template.Template
+}
+
+var _ FieldMappingInterface = (*FieldMappingTemplate)(nil)
+
+// NewFieldMappingTemplate parses the Go template and creates a new field mapping template.
+// Functions are the same as of Go template transformers, built by [gotmpl.NewFuncMap] with the policy and options.
+func NewFieldMappingTemplate(
+	source string,
+	functions *gotmpl.GoTemplateFunctions,
+	options ...gotmpl.GoTemplateTransformerOption,
+) (*FieldMappingTemplate, error) {
+	result := &FieldMappingTemplate{
+		Source:    source,
+		Functions: functions,
+	}
+
+	err := result.parse(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Type returns type of the field mapping template.
+func (FieldMappingTemplate) Type() FieldMappingType {
+	return FieldMappingTypeTemplate
+}
+
+// IsZero checks if the field mapping template is empty.
+func (fm FieldMappingTemplate) IsZero() bool {
+	return fm.Source == ""
+}
+
+// Equal checks if this instance equals the target value.
+func (fm FieldMappingTemplate) Equal(target FieldMappingTemplate) bool {
+	return fm.Source == target.Source &&
+		fm.OnError == target.OnError &&
+		goutils.EqualPtr(fm.Functions, target.Functions)
+}
+
+// Evaluate renders the template with the input data.
+func (fm FieldMappingTemplate) Evaluate(data any) (any, error) {
+	if fm.template == nil {
+		return nil, fmt.Errorf("%w: template is not parsed", ErrFieldMappingTemplateMalformed)
+	}
+
+	var builder strings.Builder
+
+	err := fm.template.Execute(&builder, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return builder.String(), nil
+}
+
+func (fm FieldMappingTemplate) errorPolicy() ErrorPolicy {
+	return fm.OnError
+}
+
+// parse parses the template with functions of the options. Calls of unknown functions are rejected.
+func (fm *FieldMappingTemplate) parse(options ...gotmpl.GoTemplateTransformerOption) error {
+	funcMap, err := gotmpl.NewFuncMap(fm.Functions, options...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFieldMappingTemplateMalformed, err)
+	}
+
+	tmpl, err := template.New(string(FieldMappingTypeTemplate)).Funcs(funcMap).Parse(fm.Source)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFieldMappingTemplateMalformed, err)
+	}
+
+	fm.template = tmpl
+
+	return nil
+}
+
+// parseUnchecked parses the template with default functions without checking if called functions exist,
+// so the template can call custom functions which are bound afterwards by [BindTemplateOptions].
+// Calls of unknown functions fail on execution.
+func (fm *FieldMappingTemplate) parseUnchecked() error {
+	funcMap, err := gotmpl.NewFuncMap(fm.Functions)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFieldMappingTemplateMalformed, err)
+	}
+
+	name := string(FieldMappingTypeTemplate)
+	tree := parse.New(name)
+	tree.Mode = parse.SkipFuncCheck
+	treeSet := map[string]*parse.Tree{}
+
+	_, err = tree.Parse(fm.Source, "", "", treeSet)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFieldMappingTemplateMalformed, err)
+	}
+
+	tmpl := template.New(name).Funcs(funcMap)
+
+	for treeName, tree := range treeSet {
+		_, err := tmpl.AddParseTree(treeName, tree)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFieldMappingTemplateMalformed, err)
+		}
+	}
+
+	fm.template = tmpl
+
+	return nil
+}
+
+// BindTemplateOptions parses templates which are reachable from the field mapping again
+// with functions of Go template options, e.g. the clock, the random source and custom functions.
+// Calls of unknown functions are rejected.
+func BindTemplateOptions(mapping FieldMapping, options ...gotmpl.GoTemplateTransformerOption) error {
+	return walkFieldMappings(mapping, func(mapping FieldMapping) error {
+		fm, ok := mapping.FieldMappingInterface.(*FieldMappingTemplate)
+		if !ok {
+			return nil
+		}
+
+		return fm.parse(options...)
+	})
+}
+
+// FieldMappingTemplateConfig represents configurations for a field mapping which renders a string with a Go template.
+type FieldMappingTemplateConfig struct {
+	// Template is the Go template which is rendered with the current input data.
+	// Functions of the sprig library and registered functions are available.
+	Template string `json:"template" yaml:"template" jsonschema:"description=Go template which is rendered with the current input data. Sprig and registered functions are available"`
+	// Functions is the policy of functions which are available to the template.
+	Functions *gotmpl.GoTemplateFunctions `json:"functions,omitempty" yaml:"functions,omitempty" jsonschema:"description=Policy of functions which are available to the template"`
+	// OnError is the behavior when the evaluation fails. Defaults to fail.
+	OnError ErrorPolicy `json:"onError,omitempty" yaml:"onError,omitempty" jsonschema:"enum=fail,enum=useDefault,enum=null,enum=omit,default=fail,description=Behavior when the evaluation fails"`
+}
+
+var _ FieldMappingConfigInterface = (*FieldMappingTemplateConfig)(nil)
+
+// Type returns the type of field mapping config.
+func (FieldMappingTemplateConfig) Type() FieldMappingType {
+	return FieldMappingTypeTemplate
+}
+
+// IsZero checks if the config is empty.
+func (fm FieldMappingTemplateConfig) IsZero() bool {
+	return fm.Template == ""
+}
+
+// Equal checks if this instance equals the target value.
+func (fm FieldMappingTemplateConfig) Equal(target FieldMappingTemplateConfig) bool {
+	return fm.Template == target.Template &&
+		fm.OnError == target.OnError &&
+		goutils.EqualPtr(fm.Functions, target.Functions)
+}
+
+// EvaluateEnv converts the config to the field mapping instance with environment variables.
+func (fm FieldMappingTemplateConfig) EvaluateEnv() (FieldMapping, error) {
+	return fm.Evaluate(goenvconf.GetOSEnv)
+}
+
+// Evaluate parses the template and converts the config to the field mapping instance.
+// Called functions are checked when Go template options are bound by [JMESTransformerConfig.Evaluate].
+func (fm FieldMappingTemplateConfig) Evaluate(_ goenvconf.GetEnvFunc) (FieldMapping, error) {
+	if fm.Template == "" {
+		return FieldMapping{}, fmt.Errorf("%w: template must not be empty", ErrFieldMappingTemplateMalformed)
+	}
+
+	if fm.OnError != "" {
+		err := fm.OnError.Validate()
+		if err != nil {
+			return FieldMapping{}, err
+		}
+	}
+
+	if fm.Functions != nil {
+		err := fm.Functions.Validate()
+		if err != nil {
+			return FieldMapping{}, fmt.Errorf("%w: %w", ErrFieldMappingTemplateMalformed, err)
+		}
+	}
+
+	result := &FieldMappingTemplate{
+		Source:    fm.Template,
+		Functions: fm.Functions,
+		OnError:   fm.OnError,
+	}
+
+	err := result.parseUnchecked()
+	if err != nil {
+		return FieldMapping{}, err
+	}
+
+	return NewFieldMapping(result), nil
+}
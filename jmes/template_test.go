@@ -0,0 +1,204 @@
+package jmes
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/hasura/goenvconf"
+	"github.com/relychan/gotransform/gotmpl"
+)
+
+func TestFieldMappingTemplate_Evaluate(t *testing.T) {
+	t.Run("render with sprig functions", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{
+			"type": "template",
+			"template": "{{ .name | title }}-{{ .tags | join \",\" }}"
+		}`)
+
+		result, err := mapping.Evaluate(map[string]any{
+			"name": "hello world",
+			"tags": []any{"a", "b"},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if result != "Hello World-a,b" {
+			t.Errorf("expected Hello World-a,b, got: %v", result)
+		}
+	})
+
+	t.Run("current item of a nested mapping", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{
+			"type": "dictionary",
+			"path": "users",
+			"key": "id",
+			"value": { "type": "template", "template": "{{ .id }}: {{ .name }}" }
+		}`)
+
+		result, err := mapping.Evaluate(map[string]any{
+			"users": []any{map[string]any{"id": 1, "name": "Ada"}},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string]any{"1": "1: Ada"}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("execution error with null policy", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{
+			"type": "object",
+			"properties": {
+				"label": {
+					"type": "template",
+					"template": "{{ fail \"invalid\" }}",
+					"onError": "null"
+				}
+			}
+		}`)
+
+		result, err := mapping.Evaluate(nil)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string]any{"label": nil}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{"type": "template", "template": "{{ fail \"invalid\" }}"}`)
+
+		_, err := mapping.Evaluate(nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		_, err := FieldMappingTemplateConfig{Template: "{{ .name "}.EvaluateEnv()
+		if !errors.Is(err, ErrFieldMappingTemplateMalformed) {
+			t.Fatalf("expected ErrFieldMappingTemplateMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("empty template", func(t *testing.T) {
+		_, err := FieldMappingTemplateConfig{}.EvaluateEnv()
+		if !errors.Is(err, ErrFieldMappingTemplateMalformed) {
+			t.Fatalf("expected ErrFieldMappingTemplateMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("unknown function on execution", func(t *testing.T) {
+		mapping := evaluateTestFieldMappingConfig(t, `{"type": "template", "template": "{{ mask .id }}"}`)
+
+		_, err := mapping.Evaluate(map[string]any{"id": "123456"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestJMESTransformerConfig_TemplateOptions(t *testing.T) {
+	evaluateConfig := func(t *testing.T, rawConfig string, options ...gotmpl.GoTemplateTransformerOption) (FieldMapping, error) {
+		t.Helper()
+
+		var config JMESTransformerConfig
+
+		err := json.Unmarshal([]byte(rawConfig), &config)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		return config.Evaluate(goenvconf.GetOSEnv, options...)
+	}
+
+	t.Run("clock and custom functions", func(t *testing.T) {
+		mapping, err := evaluateConfig(
+			t,
+			`{"template": {"type": "template", "template": "{{ now.Year }}-{{ mask .id }}"}}`,
+			gotmpl.WithClock(func() time.Time {
+				return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			}),
+			gotmpl.WithFuncs(template.FuncMap{
+				"mask": func(value string) string {
+					return "***" + value[len(value)-2:]
+				},
+			}),
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		result, err := mapping.Evaluate(map[string]any{"id": "123456"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if result != "2024-***56" {
+			t.Errorf("expected 2024-***56, got: %v", result)
+		}
+	})
+
+	t.Run("templates of definitions", func(t *testing.T) {
+		mapping, err := evaluateConfig(
+			t,
+			`{
+				"template": {"type": "ref", "ref": "label"},
+				"definitions": {"label": {"type": "template", "template": "{{ upper .name }}"}}
+			}`,
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		result, err := mapping.Evaluate(map[string]any{"name": "ada"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if result != "ADA" {
+			t.Errorf("expected ADA, got: %v", result)
+		}
+	})
+
+	t.Run("unknown function", func(t *testing.T) {
+		_, err := evaluateConfig(t, `{"template": {"type": "template", "template": "{{ mask .id }}"}}`)
+		if !errors.Is(err, ErrFieldMappingTemplateMalformed) {
+			t.Fatalf("expected ErrFieldMappingTemplateMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("function disabled by the policy", func(t *testing.T) {
+		_, err := evaluateConfig(t, `{
+			"template": {
+				"type": "template",
+				"template": "{{ env \"HOME\" }}",
+				"functions": {"policy": "safe"}
+			}
+		}`)
+		if !errors.Is(err, ErrFieldMappingTemplateMalformed) {
+			t.Fatalf("expected ErrFieldMappingTemplateMalformed, got: %v", err)
+		}
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		_, err := FieldMappingTemplateConfig{
+			Template:  "{{ .name }}",
+			Functions: &gotmpl.GoTemplateFunctions{Policy: "none"},
+		}.EvaluateEnv()
+		if !errors.Is(err, gotmpl.ErrUnsupportedFunctionPolicy) {
+			t.Fatalf("expected ErrUnsupportedFunctionPolicy, got: %v", err)
+		}
+	})
+}
@@ -1,9 +1,14 @@
 package jmes
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
 
 	"github.com/hasura/goenvconf"
+	"go.yaml.in/yaml/v4"
 )
 
 // EvaluateObjectFieldMappingEntries validate and resolve the entry mapping fields of an object.
@@ -51,3 +56,132 @@ func EvaluateObjectFieldMappingStringEntries(
 
 	return props, nil
 }
+
+// getJSONObjectKeys returns keys of a raw JSON object in the declaration order.
+func getJSONObjectKeys(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+
+	// skip the opening bracket. The object is already validated by the standard unmarshaler.
+	_, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w, expected an object key, got %v", ErrFieldMappingObjectMalformed, token)
+		}
+
+		keys = append(keys, key)
+
+		var value json.RawMessage
+
+		err = decoder.Decode(&value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// getYAMLObjectKeys returns keys of the object field in a YAML mapping node in the declaration order.
+func getYAMLObjectKeys(node *yaml.Node, field string) []string {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != field {
+			continue
+		}
+
+		valueNode := node.Content[i+1]
+		if valueNode.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		keys := make([]string, 0, len(valueNode.Content)/2)
+
+		for j := 0; j+1 < len(valueNode.Content); j += 2 {
+			keys = append(keys, valueNode.Content[j].Value)
+		}
+
+		return keys
+	}
+
+	return nil
+}
+
+// hasObjectKey checks if the value is an object which contains the key, even if its value is null.
+// orderPropertyKeys returns keys of properties with the declared keys first.
+// Keys which are not declared are appended in sorted order. Unknown and duplicated declared keys are dropped.
+func orderPropertyKeys[V any](declared []string, properties map[string]V) []string {
+	keys := make([]string, 0, len(properties))
+	seen := make(map[string]bool, len(properties))
+
+	for _, key := range declared {
+		if _, ok := properties[key]; ok && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	rest := make([]string, 0, len(properties)-len(keys))
+
+	for key := range properties {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+
+	slices.Sort(rest)
+
+	return append(keys, rest...)
+}
+
+func hasObjectKey(value any, key string) bool {
+	if oo, ok := value.(*OrderedObject); ok {
+		_, exists := oo.Get(key)
+
+		return exists
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return false
+	}
+
+	return rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key())).IsValid()
+}
+
+// hasArrayIndex checks if the value is an array which contains the index.
+// Negative indexes count from the end of the array.
+func hasArrayIndex(value any, index int) bool {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return false
+	}
+
+	if index < 0 {
+		index += rv.Len()
+	}
+
+	return index >= 0 && index < rv.Len()
+}
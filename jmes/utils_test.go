@@ -1,6 +1,7 @@
 package jmes
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/hasura/goenvconf"
@@ -149,3 +150,76 @@ func TestEvaluateObjectFieldMappingStringEntries(t *testing.T) {
 		}
 	})
 }
+
+func TestGetJSONObjectKeys(t *testing.T) {
+	keys, err := getJSONObjectKeys([]byte(`{"b": {"c": 1, "d": [1, 2]}, "a": null}`))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if !slices.Equal(keys, []string{"b", "a"}) {
+		t.Errorf("expected keys in declaration order, got: %v", keys)
+	}
+
+	keys, err = getJSONObjectKeys([]byte(`null`))
+	if err != nil || keys != nil {
+		t.Errorf("expected no keys, got: %v, %v", keys, err)
+	}
+}
+
+func TestOrderPropertyKeys(t *testing.T) {
+	properties := map[string]int{"d": 4, "c": 3, "b": 2, "a": 1}
+
+	keys := orderPropertyKeys([]string{"c", "x", "a", "c"}, properties)
+	if !slices.Equal(keys, []string{"c", "a", "b", "d"}) {
+		t.Errorf("expected declared keys first and the rest sorted, got: %v", keys)
+	}
+
+	keys = orderPropertyKeys[int](nil, nil)
+	if len(keys) != 0 {
+		t.Errorf("expected no keys, got: %v", keys)
+	}
+}
+
+func TestHasObjectKey(t *testing.T) {
+	ordered := NewOrderedObject()
+	ordered.Set("a", nil)
+
+	testCases := []struct {
+		Value    any
+		Key      string
+		Expected bool
+	}{
+		{Value: map[string]any{"a": nil}, Key: "a", Expected: true},
+		{Value: map[string]string{"a": ""}, Key: "b", Expected: false},
+		{Value: ordered, Key: "a", Expected: true},
+		{Value: []any{"a"}, Key: "a", Expected: false},
+		{Value: nil, Key: "a", Expected: false},
+	}
+
+	for _, tc := range testCases {
+		if result := hasObjectKey(tc.Value, tc.Key); result != tc.Expected {
+			t.Errorf("expected hasObjectKey(%v, %s) to be %t, got: %t", tc.Value, tc.Key, tc.Expected, result)
+		}
+	}
+}
+
+func TestHasArrayIndex(t *testing.T) {
+	testCases := []struct {
+		Value    any
+		Index    int
+		Expected bool
+	}{
+		{Value: []any{nil}, Index: 0, Expected: true},
+		{Value: []any{nil}, Index: -1, Expected: true},
+		{Value: []any{nil}, Index: 1, Expected: false},
+		{Value: []any{nil}, Index: -2, Expected: false},
+		{Value: map[string]any{}, Index: 0, Expected: false},
+	}
+
+	for _, tc := range testCases {
+		if result := hasArrayIndex(tc.Value, tc.Index); result != tc.Expected {
+			t.Errorf("expected hasArrayIndex(%v, %d) to be %t, got: %t", tc.Value, tc.Index, tc.Expected, result)
+		}
+	}
+}
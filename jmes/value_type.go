@@ -0,0 +1,84 @@
+package jmes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+)
+
+// ValueType represents the expected type enum of a field mapping result.
+type ValueType string
+
+const (
+	ValueTypeString  ValueType = "string"
+	ValueTypeNumber  ValueType = "number"
+	ValueTypeInteger ValueType = "integer"
+	ValueTypeBoolean ValueType = "boolean"
+	ValueTypeObject  ValueType = "object"
+	ValueTypeArray   ValueType = "array"
+)
+
+var enumValuesValueType = []ValueType{
+	ValueTypeString,
+	ValueTypeNumber,
+	ValueTypeInteger,
+	ValueTypeBoolean,
+	ValueTypeObject,
+	ValueTypeArray,
+}
+
+var errUnsupportedValueType = errors.New("unsupported value type")
+
+// Validate checks if the value type is valid.
+func (vt ValueType) Validate() error {
+	if !slices.Contains(enumValuesValueType, vt) {
+		return fmt.Errorf("%w: %w: %s", ErrFieldMappingEntryMalformed, errUnsupportedValueType, vt)
+	}
+
+	return nil
+}
+
+// Check returns an error if the value does not match the value type. Null values are always valid.
+func (vt ValueType) Check(value any) error {
+	if value == nil || vt.matches(value) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w, expected value of type %s, got %s",
+		ErrFieldMappingEntryMalformed,
+		vt,
+		reflect.TypeOf(value),
+	)
+}
+
+func (vt ValueType) matches(value any) bool {
+	if _, ok := value.(*OrderedObject); ok {
+		return vt == ValueTypeObject
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch vt {
+	case ValueTypeString:
+		return rv.Kind() == reflect.String
+	case ValueTypeBoolean:
+		return rv.Kind() == reflect.Bool
+	case ValueTypeNumber:
+		return rv.CanInt() || rv.CanUint() || rv.CanFloat()
+	case ValueTypeInteger:
+		if rv.CanFloat() {
+			return rv.Float() == math.Trunc(rv.Float())
+		}
+
+		return rv.CanInt() || rv.CanUint()
+	case ValueTypeObject:
+		return rv.Kind() == reflect.Map || rv.Kind() == reflect.Struct
+	case ValueTypeArray:
+		return rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array
+	default:
+		return false
+	}
+}
@@ -0,0 +1,63 @@
+package jmes
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValueType_Validate(t *testing.T) {
+	for _, vt := range enumValuesValueType {
+		if err := vt.Validate(); err != nil {
+			t.Errorf("expected no error for %s, got: %v", vt, err)
+		}
+	}
+
+	if err := ValueType("date").Validate(); !errors.Is(err, ErrFieldMappingEntryMalformed) {
+		t.Errorf("expected error to be ErrFieldMappingEntryMalformed, got: %v", err)
+	}
+}
+
+func TestValueType_Check(t *testing.T) {
+	testCases := []struct {
+		ValueType ValueType
+		Value     any
+		Valid     bool
+	}{
+		{ValueType: ValueTypeString, Value: "foo", Valid: true},
+		{ValueType: ValueTypeString, Value: 1, Valid: false},
+		{ValueType: ValueTypeNumber, Value: 1.5, Valid: true},
+		{ValueType: ValueTypeNumber, Value: int64(1), Valid: true},
+		{ValueType: ValueTypeNumber, Value: "1", Valid: false},
+		{ValueType: ValueTypeInteger, Value: float64(2), Valid: true},
+		{ValueType: ValueTypeInteger, Value: 2.5, Valid: false},
+		{ValueType: ValueTypeInteger, Value: uint8(2), Valid: true},
+		{ValueType: ValueTypeBoolean, Value: false, Valid: true},
+		{ValueType: ValueTypeBoolean, Value: "true", Valid: false},
+		{ValueType: ValueTypeObject, Value: map[string]any{}, Valid: true},
+		{ValueType: ValueTypeObject, Value: []any{}, Valid: false},
+		{ValueType: ValueTypeObject, Value: NewOrderedObject(), Valid: true},
+		{ValueType: ValueTypeArray, Value: NewOrderedObject(), Valid: false},
+		{ValueType: ValueTypeArray, Value: []string{"a"}, Valid: true},
+		{ValueType: ValueTypeArray, Value: map[string]any{}, Valid: false},
+		{ValueType: ValueTypeArray, Value: nil, Valid: true},
+	}
+
+	for _, tc := range testCases {
+		err := tc.ValueType.Check(tc.Value)
+		if tc.Valid && err != nil {
+			t.Errorf("%s: expected %v to be valid, got: %v", tc.ValueType, tc.Value, err)
+		}
+
+		if !tc.Valid && !errors.Is(err, ErrFieldMappingEntryMalformed) {
+			t.Errorf("%s: expected %v to be invalid, got: %v", tc.ValueType, tc.Value, err)
+		}
+	}
+}
+
+func TestValueType_CheckMessage(t *testing.T) {
+	err := ValueTypeObject.Check("foo")
+	if err == nil || !strings.Contains(err.Error(), "expected value of type object, got string") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
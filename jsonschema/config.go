@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/invopop/jsonschema"
 	"github.com/relychan/gotransform"
+	"github.com/relychan/gotransform/gotmpl"
 	"github.com/relychan/gotransform/transformtypes"
 	orderedmap "github.com/wk8/go-ordered-map/v2"
 )
@@ -21,6 +22,27 @@ func (TemplateTransformerConfig) JSONSchema() *jsonschema.Schema {
 		Description: "Template content to be transformed",
 		Ref:         "#/$defs/FieldMappingConfig",
 	})
+	jmesPathProps.Set("definitions", &jsonschema.Schema{
+		Description: "Named field mappings which can be reused in the template with ref mappings",
+		Type:        "object",
+		AdditionalProperties: &jsonschema.Schema{
+			Ref: "#/$defs/FieldMappingConfig",
+		},
+	})
+	jmesPathProps.Set("variables", &jsonschema.Schema{
+		Description: "Constant values or environment variables which are addressable with $vars in JMESPath expressions",
+		Type:        "object",
+		AdditionalProperties: &jsonschema.Schema{
+			Ref: "#/$defs/EnvAny",
+		},
+	})
+	jmesPathProps.Set("lookupTables", &jsonschema.Schema{
+		Description: "Named tables which translate values of field mappings with lookup options",
+		Type:        "object",
+		AdditionalProperties: &jsonschema.Schema{
+			Type: "object",
+		},
+	})
 
 	goTemplateProps := orderedmap.New[string, *jsonschema.Schema]()
 	goTemplateProps.Set("type", &jsonschema.Schema{
@@ -36,6 +58,83 @@ func (TemplateTransformerConfig) JSONSchema() *jsonschema.Schema {
 		Description: "Template content to be transformed",
 		Type:        "string",
 	})
+	goTemplateProps.Set("decodeXML", &jsonschema.Schema{
+		Description: "Convert the rendered XML document to a map representation. Otherwise the document is validated and returned as a string",
+		Type:        "boolean",
+	})
+	goTemplateProps.Set("templateFile", &jsonschema.Schema{
+		Description: "Path or glob pattern of template files. Each file is a template named by its base name and the first matched file is executed unless the entrypoint is set",
+		Type:        "string",
+	})
+	goTemplateProps.Set("templates", &jsonschema.Schema{
+		Description: "Named templates which are usable via the template action",
+		Type:        "object",
+		AdditionalProperties: &jsonschema.Schema{
+			Type: "string",
+		},
+	})
+	goTemplateProps.Set("entrypoint", &jsonschema.Schema{
+		Description: "Name of the template to be executed. Defaults to the main template",
+		Type:        "string",
+	})
+	goTemplateProps.Set("partials", &jsonschema.Schema{
+		Description: "Paths or glob patterns of files which define shared templates",
+		Type:        "array",
+		Items: &jsonschema.Schema{
+			Type: "string",
+		},
+	})
+	goTemplateProps.Set("functions", goTemplateFunctionsSchema())
+	goTemplateLimitsProps := orderedmap.New[string, *jsonschema.Schema]()
+	goTemplateLimitsProps.Set("maxOutputBytes", &jsonschema.Schema{
+		Description: "Maximum size of the rendered output in bytes which is checked on writes. The repeat function also fails if its result exceeds the limit. Unlimited if zero",
+		Type:        "integer",
+		Minimum:     "0",
+	})
+	goTemplateLimitsProps.Set("maxExecutionTime", &jsonschema.Schema{
+		Description: "Maximum duration of the execution in the Go duration format such as 500ms or 2s. Unlimited if empty",
+		Type:        "string",
+		Pattern:     `^(\d+(\.\d+)?(ns|us|µs|ms|s|m|h))+$`,
+	})
+	goTemplateLimitsProps.Set("maxIterations", &jsonschema.Schema{
+		Description: "Maximum number of iterations of all range actions in total. The until and untilStep functions also fail if the list is longer than the limit. Unlimited if zero",
+		Type:        "integer",
+		Minimum:     "0",
+	})
+	goTemplateProps.Set("limits", &jsonschema.Schema{
+		Description: "Resource limits of the template execution",
+		Type:        "object",
+		Properties:  goTemplateLimitsProps,
+	})
+	goTemplateDelimsProps := orderedmap.New[string, *jsonschema.Schema]()
+	goTemplateDelimsProps.Set("left", &jsonschema.Schema{
+		Description: "Left action delimiter",
+		Type:        "string",
+	})
+	goTemplateDelimsProps.Set("right", &jsonschema.Schema{
+		Description: "Right action delimiter",
+		Type:        "string",
+	})
+	goTemplateProps.Set("delims", &jsonschema.Schema{
+		Description: "Custom action delimiters of the template",
+		Type:        "object",
+		Properties:  goTemplateDelimsProps,
+		Required:    []string{"left", "right"},
+	})
+	goTemplateProps.Set("missingKey", &jsonschema.Schema{
+		Description: "Behavior when a map is indexed with a key that is not present",
+		Type:        "string",
+		Enum: []any{
+			gotmpl.MissingKeyDefault,
+			gotmpl.MissingKeyZero,
+			gotmpl.MissingKeyError,
+		},
+		Default: gotmpl.MissingKeyDefault,
+	})
+	goTemplateProps.Set("csvHeader", &jsonschema.Schema{
+		Description: "Decode the rendered CSV rows to records keyed by the columns of the first row. Otherwise rows are returned as arrays of strings",
+		Type:        "boolean",
+	})
 
 	return &jsonschema.Schema{
 		OneOf: []*jsonschema.Schema{
@@ -51,8 +150,43 @@ func (TemplateTransformerConfig) JSONSchema() *jsonschema.Schema {
 				Title:       "TemplateTransformerGoTemplateConfig",
 				Description: "Transform responses using the standard Go template",
 				Properties:  goTemplateProps,
-				Required:    []string{"type", "template", "contentType"},
+				Required:    []string{"type", "contentType"},
+				Not: &jsonschema.Schema{
+					Description: "template and templateFile are mutually exclusive",
+					Required:    []string{"template", "templateFile"},
+				},
 			},
 		},
 	}
 }
+
+// goTemplateFunctionsSchema returns the schema of the function policy of Go templates.
+func goTemplateFunctionsSchema() *jsonschema.Schema {
+	props := orderedmap.New[string, *jsonschema.Schema]()
+	props.Set("policy", &jsonschema.Schema{
+		Description: "Base set of available functions. The safe policy disables functions which read environment variables or perform IO or generate random values",
+		Type:        "string",
+		Enum:        []any{gotmpl.FunctionPolicyFull, gotmpl.FunctionPolicySafe},
+		Default:     gotmpl.FunctionPolicyFull,
+	})
+	props.Set("allow", &jsonschema.Schema{
+		Description: "Restrict available functions of the base set to the list if not empty",
+		Type:        "array",
+		Items: &jsonschema.Schema{
+			Type: "string",
+		},
+	})
+	props.Set("deny", &jsonschema.Schema{
+		Description: "Functions to be disabled. Names must be sprig or registered or custom functions",
+		Type:        "array",
+		Items: &jsonschema.Schema{
+			Type: "string",
+		},
+	})
+
+	return &jsonschema.Schema{
+		Description: "Policy of functions which are available to the template. Templates which call disabled functions fail to be parsed",
+		Type:        "object",
+		Properties:  props,
+	}
+}
@@ -34,6 +34,11 @@ func jsonSchemaConfiguration() error {
 	for _, externalType := range []any{
 		jmes.FieldMappingObjectConfig{},
 		jmes.FieldMappingEntryConfig{},
+		jmes.FieldMappingRefConfig{},
+		jmes.FieldMappingDiscriminatorConfig{},
+		jmes.FieldMappingDictionaryConfig{},
+		jmes.FieldMappingEntriesConfig{},
+		jmes.FieldMappingTemplateConfig{},
 	} {
 		externalSchema := r.Reflect(externalType)
 
@@ -65,6 +70,57 @@ func jsonSchemaConfiguration() error {
 		"type",
 	)
 
+	reflectSchema.Definitions["FieldMappingRefConfig"].Properties.Set("type", &jsonschema.Schema{
+		Description: "Type of the field mapping config",
+		Type:        "string",
+		Enum:        []any{jmes.FieldMappingTypeRef},
+	})
+	reflectSchema.Definitions["FieldMappingRefConfig"].Required = append(
+		reflectSchema.Definitions["FieldMappingRefConfig"].Required,
+		"type",
+	)
+
+	reflectSchema.Definitions["FieldMappingDiscriminatorConfig"].Properties.Set("type", &jsonschema.Schema{
+		Description: "Type of the field mapping config",
+		Type:        "string",
+		Enum:        []any{jmes.FieldMappingTypeDiscriminator},
+	})
+	reflectSchema.Definitions["FieldMappingDiscriminatorConfig"].Required = append(
+		reflectSchema.Definitions["FieldMappingDiscriminatorConfig"].Required,
+		"type",
+	)
+
+	reflectSchema.Definitions["FieldMappingDictionaryConfig"].Properties.Set("type", &jsonschema.Schema{
+		Description: "Type of the field mapping config",
+		Type:        "string",
+		Enum:        []any{jmes.FieldMappingTypeDictionary},
+	})
+	reflectSchema.Definitions["FieldMappingDictionaryConfig"].Required = append(
+		reflectSchema.Definitions["FieldMappingDictionaryConfig"].Required,
+		"type",
+	)
+
+	reflectSchema.Definitions["FieldMappingEntriesConfig"].Properties.Set("type", &jsonschema.Schema{
+		Description: "Type of the field mapping config",
+		Type:        "string",
+		Enum:        []any{jmes.FieldMappingTypeEntries},
+	})
+	reflectSchema.Definitions["FieldMappingEntriesConfig"].Required = append(
+		reflectSchema.Definitions["FieldMappingEntriesConfig"].Required,
+		"type",
+	)
+
+	reflectSchema.Definitions["FieldMappingTemplateConfig"].Properties.Set("type", &jsonschema.Schema{
+		Description: "Type of the field mapping config",
+		Type:        "string",
+		Enum:        []any{jmes.FieldMappingTypeTemplate},
+	})
+	reflectSchema.Definitions["FieldMappingTemplateConfig"].Required = append(
+		reflectSchema.Definitions["FieldMappingTemplateConfig"].Required,
+		"type",
+	)
+	reflectSchema.Definitions["GoTemplateFunctions"] = goTemplateFunctionsSchema()
+
 	reflectSchema.Definitions["FieldMappingConfig"] = &jsonschema.Schema{
 		Description: "Represents a generic field mapping config",
 		OneOf: []*jsonschema.Schema{
@@ -76,6 +132,26 @@ func jsonSchemaConfiguration() error {
 				Description: "The mapping configuration for an entry field",
 				Ref:         "#/$defs/FieldMappingEntryConfig",
 			},
+			{
+				Description: "Reference to a named definition of the transformer config",
+				Ref:         "#/$defs/FieldMappingRefConfig",
+			},
+			{
+				Description: "Field mapping which is chosen by a discriminator value of the input data",
+				Ref:         "#/$defs/FieldMappingDiscriminatorConfig",
+			},
+			{
+				Description: "Conversion from an array to an object whose keys are derived from each item",
+				Ref:         "#/$defs/FieldMappingDictionaryConfig",
+			},
+			{
+				Description: "Conversion from an object to an array of key-value entries",
+				Ref:         "#/$defs/FieldMappingEntriesConfig",
+			},
+			{
+				Description: "String which is rendered from the input data with a Go template",
+				Ref:         "#/$defs/FieldMappingTemplateConfig",
+			},
 		},
 	}
 
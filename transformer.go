@@ -3,6 +3,7 @@ package gotransform
 
 import (
 	"fmt"
+	"text/template"
 
 	"github.com/hasura/goenvconf"
 	"github.com/relychan/gotransform/gotmpl"
@@ -20,27 +21,61 @@ type TemplateTransformer interface {
 	Transform(data any) (any, error)
 }
 
+// WarningTransformer is implemented by template transformers which can report non-fatal errors of the transformation,
+// e.g. errors of fields which are tolerated by their error policies.
+type WarningTransformer interface {
+	TemplateTransformer
+	// TransformWithWarnings transforms data and returns non-fatal errors as warnings alongside the result.
+	TransformWithWarnings(data any) (any, []jmes.FieldWarning, error)
+}
+
+var _ WarningTransformer = (*jmes.JMESTemplateTransformer)(nil)
+
+// TransformWithWarnings transforms data with the template transformer and returns warnings if the transformer supports them.
+// Warnings are always empty for transformers which do not implement [WarningTransformer].
+func TransformWithWarnings(transformer TemplateTransformer, data any) (any, []jmes.FieldWarning, error) {
+	if wt, ok := transformer.(WarningTransformer); ok {
+		return wt.TransformWithWarnings(data)
+	}
+
+	result, err := transformer.Transform(data)
+
+	return result, nil, err
+}
+
 // NewTransformerFromConfig creates a template transformer from configuration.
 func NewTransformerFromConfig(
 	name string,
 	config TemplateTransformerConfig,
 	getEnvFunc goenvconf.GetEnvFunc,
+	options ...TransformerOption,
 ) (TemplateTransformer, error) {
 	err := config.Validate()
 	if err != nil {
 		return nil, err
 	}
 
+	opts := &transformerOptions{}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
 	switch conf := config.Interface().(type) {
 	case *jmes.JMESTransformerConfig:
-		fieldMapping, err := conf.Template.Evaluate(getEnvFunc)
+		fieldMapping, err := conf.Evaluate(getEnvFunc, opts.goTemplateOptions...)
 		if err != nil {
 			return nil, err
 		}
 
-		return jmes.NewJMESTemplateTransformer(fieldMapping), nil
+		variables, err := conf.EvaluateVariables(getEnvFunc)
+		if err != nil {
+			return nil, err
+		}
+
+		return jmes.NewJMESTemplateTransformer(fieldMapping, jmes.WithVariables(variables)), nil
 	case *gotmpl.GoTemplateTransformerConfig:
-		return gotmpl.NewGoTemplateTransformer(name, conf)
+		return gotmpl.NewGoTemplateTransformer(name, conf, opts.goTemplateOptions...)
 	default:
 		return nil, fmt.Errorf(
 			"%w: %s",
@@ -50,6 +85,26 @@ func NewTransformerFromConfig(
 	}
 }
 
+// TransformerOption is a function to configure the construction of template transformers.
+type TransformerOption func(*transformerOptions)
+
+type transformerOptions struct {
+	goTemplateOptions []gotmpl.GoTemplateTransformerOption
+}
+
+// WithGoTemplateOptions sets options of Go template transformers, e.g. the file system of template files.
+// Functions of the options also apply to Go templates of JMESPath field mappings.
+func WithGoTemplateOptions(options ...gotmpl.GoTemplateTransformerOption) TransformerOption {
+	return func(opts *transformerOptions) {
+		opts.goTemplateOptions = append(opts.goTemplateOptions, options...)
+	}
+}
+
+// WithTemplateFuncs adds custom functions to Go templates in addition to sprig and registered functions.
+func WithTemplateFuncs(funcs template.FuncMap) TransformerOption {
+	return WithGoTemplateOptions(gotmpl.WithFuncs(funcs))
+}
+
 // EqualTemplateTransformer checks if both template transformers are equal.
 func EqualTemplateTransformer(a, b TemplateTransformer) bool {
 	if a == b {
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"text/template"
 
 	"github.com/hasura/goenvconf"
 	"github.com/relychan/gotransform/gotmpl"
@@ -114,6 +116,70 @@ func TestTransformerYAML(t *testing.T) {
 			},
 			Expected: []string{"Jon", "Tony"},
 		},
+		{
+			File: "testdata/jmes_definitions.yaml",
+			Input: map[string]any{
+				"data": map[string]any{
+					"shipping": map[string]any{"city": "Hanoi", "zip": "100000"},
+					"billing":  map[string]any{"city": "Saigon"},
+				},
+			},
+			Expected: map[string]any{
+				"shipping": map[string]any{"city": "Hanoi"},
+				"billing":  map[string]any{"city": "Saigon"},
+			},
+		},
+		{
+			File: "testdata/jmes_variables.yaml",
+			Input: map[string]any{
+				"data": map[string]any{
+					"id":    "order-1",
+					"items": []any{map[string]any{"name": "pen"}},
+				},
+			},
+			Expected: map[string]any{
+				"items": []any{
+					map[string]any{"name": "pen", "orderId": "order-1", "currency": "USD"},
+				},
+			},
+		},
+		{
+			File: "testdata/jmes_lookup.yaml",
+			Input: map[string]any{
+				"data": map[string]any{
+					"status":   "P",
+					"priority": 3,
+				},
+			},
+			Expected: map[string]any{
+				"status":   "pending",
+				"priority": "normal",
+			},
+		},
+		{
+			File: "testdata/jmes_discriminator.yaml",
+			Input: map[string]any{
+				"id":   "evt-1",
+				"type": "order.cancelled",
+				"body": map[string]any{"reason": "out of stock"},
+			},
+			Expected: map[string]any{
+				"id":    "evt-1",
+				"event": map[string]any{"reason": "out of stock"},
+			},
+		},
+		{
+			File: "testdata/jmes_template.yaml",
+			Input: map[string]any{
+				"id":        1,
+				"firstName": "Ada",
+				"lastName":  "Lovelace",
+			},
+			Expected: map[string]any{
+				"id":       1,
+				"fullName": "Ada LOVELACE",
+			},
+		},
 		{
 			File: "testdata/gotmpl.yaml",
 			Input: map[string]any{
@@ -157,3 +223,122 @@ func TestTransformerYAML(t *testing.T) {
 		})
 	}
 }
+
+func TestTransformerWithTemplateFuncs(t *testing.T) {
+	config := TemplateTransformerConfig{
+		TemplateTransformerConfig: &gotmpl.GoTemplateTransformerConfig{
+			ContentType: "text/plain",
+			Template:    `{{ .id | mask }}`,
+		},
+	}
+
+	transformer, err := NewTransformerFromConfig(
+		"test",
+		config,
+		goenvconf.GetOSEnv,
+		WithTemplateFuncs(template.FuncMap{
+			"mask": func(value string) string {
+				return strings.Repeat("*", len(value)-2) + value[len(value)-2:]
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	result, err := transformer.Transform(map[string]any{"id": "123456"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if result != "****56" {
+		t.Errorf("expected ****56, got: %v", result)
+	}
+
+	jmesTransformer, err := NewTransformerFromConfig(
+		"test",
+		TemplateTransformerConfig{
+			TemplateTransformerConfig: &jmes.JMESTransformerConfig{
+				Template: jmes.NewFieldMappingConfig(&jmes.FieldMappingTemplateConfig{
+					Template: `{{ .id | mask }}`,
+				}),
+			},
+		},
+		goenvconf.GetOSEnv,
+		WithTemplateFuncs(template.FuncMap{
+			"mask": func(value string) string {
+				return strings.Repeat("*", len(value)-2) + value[len(value)-2:]
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	result, err = jmesTransformer.Transform(map[string]any{"id": "123456"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if result != "****56" {
+		t.Errorf("expected ****56, got: %v", result)
+	}
+}
+
+func TestTransformWithWarnings(t *testing.T) {
+	path := "count"
+	jmesConfig := TemplateTransformerConfig{
+		TemplateTransformerConfig: &jmes.JMESTransformerConfig{
+			Template: jmes.NewFieldMappingConfig(&jmes.FieldMappingObjectConfig{
+				Properties: map[string]jmes.FieldMappingConfig{
+					"count": jmes.NewFieldMappingConfig(&jmes.FieldMappingEntryConfig{
+						Path:    &path,
+						Cast:    &jmes.FieldCast{Type: jmes.CastTypeInteger},
+						OnError: jmes.ErrorPolicyNull,
+					}),
+				},
+			}),
+		},
+	}
+
+	transformer, err := NewTransformerFromConfig("test", jmesConfig, goenvconf.GetOSEnv)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if _, ok := transformer.(WarningTransformer); !ok {
+		t.Fatalf("expected the JMESPath transformer to implement WarningTransformer")
+	}
+
+	result, warnings, err := TransformWithWarnings(transformer, map[string]any{"count": "many"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, map[string]any{"count": nil}) {
+		t.Errorf("expected count to be null, got: %v", result)
+	}
+
+	if len(warnings) != 1 || warnings[0].Property != "count" {
+		t.Errorf("expected a warning of count, got: %v", warnings)
+	}
+
+	goTransformer, err := NewTransformerFromConfig("test", TemplateTransformerConfig{
+		TemplateTransformerConfig: &gotmpl.GoTemplateTransformerConfig{
+			ContentType: "text/plain",
+			Template:    `{{ .count }}`,
+		},
+	}, goenvconf.GetOSEnv)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	result, warnings, err = TransformWithWarnings(goTransformer, map[string]any{"count": "many"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if result != "many" || len(warnings) != 0 {
+		t.Errorf("expected the result without warnings, got: %v, %v", result, warnings)
+	}
+}
@@ -0,0 +1,158 @@
+// Package xmlmap decodes XML documents to generic map representations.
+//
+// Documents are decoded with the following conventions:
+//
+//   - The result is an object with the local name of the root element as the only key.
+//   - Attributes are keys of the element object, prefixed with @, e.g. @id.
+//   - An element without attributes and child elements is decoded to its text content.
+//   - The text content of an element with attributes or child elements is stored in the #text key if not blank.
+//   - Child elements are keyed by their local names. Repeated elements are grouped into an array in the document order.
+//   - Namespace prefixes are dropped and all values are strings. Leading and trailing spaces of texts are trimmed.
+package xmlmap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// AttributePrefix is the prefix of attribute keys in the map representation.
+	AttributePrefix = "@"
+	// TextKey is the key of the text content of elements which have attributes or child elements.
+	TextKey = "#text"
+)
+
+// ErrMalformedDocument occurs when the XML document is not well-formed.
+var ErrMalformedDocument = errors.New("malformed XML document")
+
+// Validate checks if the XML document is well-formed and has exactly one root element.
+func Validate(data []byte) error {
+	_, err := Unmarshal(data)
+
+	return err
+}
+
+// Unmarshal decodes the XML document to the map representation.
+func Unmarshal(data []byte) (map[string]any, error) {
+	return Decode(bytes.NewReader(data))
+}
+
+// Decode reads the XML document from the reader and decodes it to the map representation.
+func Decode(reader io.Reader) (map[string]any, error) {
+	decoder := xml.NewDecoder(reader)
+
+	var (
+		root  *element
+		stack []*element
+	)
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedDocument, err)
+		}
+
+		switch tok := token.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 && root != nil {
+				return nil, fmt.Errorf("%w: multiple root elements", ErrMalformedDocument)
+			}
+
+			elem := newElement(tok)
+
+			if len(stack) > 0 {
+				stack[len(stack)-1].children = append(stack[len(stack)-1].children, elem)
+			} else {
+				root = elem
+			}
+
+			stack = append(stack, elem)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(tok)
+			} else if len(bytes.TrimSpace(tok)) > 0 {
+				return nil, fmt.Errorf("%w: text outside of the root element", ErrMalformedDocument)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("%w: root element is required", ErrMalformedDocument)
+	}
+
+	return map[string]any{
+		root.name: root.value(),
+	}, nil
+}
+
+type element struct {
+	name     string
+	attrs    []xml.Attr
+	children []*element
+	text     strings.Builder
+}
+
+func newElement(start xml.StartElement) *element {
+	elem := &element{
+		name: start.Name.Local,
+	}
+
+	for _, attr := range start.Attr {
+		// Namespace declarations are not data of the document.
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+
+		elem.attrs = append(elem.attrs, attr)
+	}
+
+	return elem
+}
+
+func (e *element) value() any {
+	text := strings.TrimSpace(e.text.String())
+
+	if len(e.attrs) == 0 && len(e.children) == 0 {
+		return text
+	}
+
+	result := make(map[string]any, len(e.attrs)+len(e.children)+1)
+
+	for _, attr := range e.attrs {
+		result[AttributePrefix+attr.Name.Local] = attr.Value
+	}
+
+	for _, child := range e.children {
+		value := child.value()
+
+		existing, ok := result[child.name]
+		if !ok {
+			result[child.name] = value
+
+			continue
+		}
+
+		// Element values are never arrays, so an array means that the element is already repeated.
+		if items, isArray := existing.([]any); isArray {
+			result[child.name] = append(items, value)
+		} else {
+			result[child.name] = []any{existing, value}
+		}
+	}
+
+	if text != "" {
+		result[TextKey] = text
+	}
+
+	return result
+}
@@ -0,0 +1,89 @@
+package xmlmap
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("conventions", func(t *testing.T) {
+		document := `<?xml version="1.0" encoding="UTF-8"?>
+<!-- orders -->
+<order id="1" xmlns="urn:orders" xmlns:x="urn:extra">
+	<customer>Ada</customer>
+	<item sku="a">first</item>
+	<item sku="b"/>
+	<x:note>  fragile  </x:note>
+	<empty/>
+	<address><city>London</city></address>
+</order>`
+
+		result, err := Unmarshal([]byte(document))
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string]any{
+			"order": map[string]any{
+				"@id":      "1",
+				"customer": "Ada",
+				"item": []any{
+					map[string]any{"@sku": "a", "#text": "first"},
+					map[string]any{"@sku": "b"},
+				},
+				"note":    "fragile",
+				"empty":   "",
+				"address": map[string]any{"city": "London"},
+			},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	t.Run("repeated elements more than twice", func(t *testing.T) {
+		result, err := Unmarshal([]byte(`<list><v>1</v><v>2</v><v>3</v></list>`))
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		expected := map[string]any{
+			"list": map[string]any{"v": []any{"1", "2", "3"}},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %v, got: %v", expected, result)
+		}
+	})
+
+	for name, document := range map[string]string{
+		"empty document":      "",
+		"unclosed element":    "<a><b></a>",
+		"multiple roots":      "<a/><b/>",
+		"text outside root":   "<a/>text",
+		"invalid attribute":   `<a b=c/>`,
+		"mismatched elements": "<a></b>",
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := Validate([]byte(document))
+			if !errors.Is(err, ErrMalformedDocument) {
+				t.Fatalf("expected ErrMalformedDocument, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	result, err := Decode(strings.NewReader(`<greeting lang="en">hello</greeting>`))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := map[string]any{
+		"greeting": map[string]any{"@lang": "en", "#text": "hello"},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("expected %v, got: %v", expected, result)
+	}
+}